@@ -0,0 +1,64 @@
+package importblock
+
+import (
+	"context"
+	"errors"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/StateOfDenial/tfpd/internal/config"
+)
+
+func flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "provider",
+			Usage: "the provider the resource belongs to",
+		},
+		&cli.StringFlag{
+			Name:  "version",
+			Usage: "what version of the provider to read the docs from",
+		},
+		&cli.BoolFlag{
+			Name:  "stdin",
+			Usage: "read one ID per line from stdin",
+		},
+		&cli.BoolFlag{
+			Name:  "explain",
+			Usage: "only print the documented import ID format",
+		},
+	}
+}
+
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:      "import-block",
+		Usage:     "generate Terraform 1.5+ import blocks using a resource's documented ID format",
+		ArgsUsage: "<resource_type> <name> [id...]",
+		Flags:     flags(),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			args := cmd.Args().Slice()
+			if len(args) < 1 || (len(args) < 2 && !cmd.Bool("explain")) {
+				return errors.New("usage: tfpd import-block <resource_type> <name> [id...]")
+			}
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+			opts := options{
+				resourceType: args[0],
+				provider:     cmd.String("provider"),
+				version:      cmd.String("version"),
+				stdin:        cmd.Bool("stdin"),
+				explain:      cmd.Bool("explain"),
+				mouse:        cfg.MouseEnabled(),
+			}
+			if len(args) > 1 {
+				opts.name = args[1]
+				opts.ids = args[2:]
+			}
+
+			return command(opts)
+		},
+	}
+}
@@ -0,0 +1,95 @@
+package importblock
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/StateOfDenial/tfpd/internal/docs"
+	"github.com/StateOfDenial/tfpd/internal/hclgen"
+	"github.com/StateOfDenial/tfpd/internal/lookup"
+)
+
+type options struct {
+	resourceType string
+	name         string
+	ids          []string
+	provider     string
+	version      string
+	stdin        bool
+	explain      bool
+	mouse        bool
+}
+
+func readIds() ([]string, error) {
+	var ids []string
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		if id := strings.TrimSpace(scanner.Text()); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids, scanner.Err()
+}
+
+func explain(spec docs.ImportSpec) {
+	fmt.Fprintln(os.Stderr, spec.Description)
+	for _, e := range spec.Examples {
+		fmt.Fprintln(os.Stderr, "  e.g. "+e)
+	}
+}
+
+func command(opts options) error {
+	_, content, err := lookup.GetDoc(lookup.Options{
+		Provider: opts.provider,
+		Version:  opts.version,
+		Resource: opts.resourceType,
+		Category: "resources",
+		Mouse:    opts.mouse,
+	})
+	if err != nil {
+		return err
+	}
+	spec := docs.ParseImport(content)
+	if spec.Description == "" && len(spec.Examples) == 0 {
+		return errors.New(opts.resourceType + " does not document an Import section")
+	}
+	if opts.explain {
+		explain(spec)
+		return nil
+	}
+
+	ids := opts.ids
+	if opts.stdin {
+		stdinIds, err := readIds()
+		if err != nil {
+			return err
+		}
+		ids = append(ids, stdinIds...)
+	}
+	if len(ids) == 0 {
+		return errors.New("no ids given, pass them as arguments or use --stdin")
+	}
+
+	mismatched := false
+	var blocks []string
+	for i, id := range ids {
+		if err := spec.Check(id); err != nil {
+			fmt.Fprintln(os.Stderr, "warning: "+err.Error())
+			mismatched = true
+		}
+		name := opts.name
+		if len(ids) > 1 {
+			name += "_" + strconv.Itoa(i+1)
+		}
+		blocks = append(blocks, hclgen.ImportBlock(opts.resourceType, name, id))
+	}
+	if mismatched {
+		explain(spec)
+	}
+	fmt.Print(strings.Join(blocks, "\n"))
+	return nil
+}
@@ -0,0 +1,58 @@
+package pick
+
+import (
+	"context"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/StateOfDenial/tfpd/internal/config"
+)
+
+func flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "query",
+			Usage: "the search to start with",
+		},
+		&cli.BoolFlag{
+			Name:  "multi",
+			Usage: "pick several lines with Tab",
+		},
+		&cli.StringFlag{
+			Name:  "preview",
+			Usage: "a shell command whose output previews the highlighted line, with {} replaced by the line",
+		},
+		&cli.BoolFlag{
+			Name:  "select-1",
+			Usage: "print the only match for --query without showing the finder",
+		},
+		&cli.BoolFlag{
+			Name:  "exit-0",
+			Usage: "exit straight away if nothing matches --query",
+		},
+	}
+}
+
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:  "pick",
+		Usage: "fuzzy find lines read from stdin and print the chosen ones",
+		Flags: flags(),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+			opts := options{
+				query:     cmd.String("query"),
+				multi:     cmd.Bool("multi"),
+				preview:   cmd.String("preview"),
+				selectOne: cmd.Bool("select-1"),
+				exitZero:  cmd.Bool("exit-0"),
+				mouse:     cfg.MouseEnabled(),
+			}
+
+			return command(opts)
+		},
+	}
+}
@@ -0,0 +1,91 @@
+package pick
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/StateOfDenial/tfpd/pkg/fuzzy"
+)
+
+const maxLineLength = 1024 * 1024
+
+type options struct {
+	query     string
+	multi     bool
+	preview   string
+	selectOne bool
+	exitZero  bool
+	mouse     bool
+}
+
+// readLines sends the lines of r as they're read. The returned func reports
+// what stopped the reading early, such as a line over maxLineLength, once
+// the channel is closed.
+func readLines(r io.Reader) (<-chan string, func() error) {
+	lines := make(chan string)
+	var err error
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(nil, maxLineLength)
+		for scanner.Scan() {
+			lines <- strings.TrimSuffix(scanner.Text(), "\r")
+		}
+		if scanner.Err() != nil {
+			err = fmt.Errorf("reading stdin: %w", scanner.Err())
+		}
+	}()
+	return lines, func() error { return err }
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func preview(template, line string) string {
+	out, err := exec.Command("sh", "-c", strings.ReplaceAll(template, "{}", shellQuote(line))).CombinedOutput()
+	if err != nil {
+		return string(out) + "\n" + err.Error()
+	}
+	return string(out)
+}
+
+func command(opts options) error {
+	if stat, err := os.Stdin.Stat(); err == nil && stat.Mode()&os.ModeCharDevice != 0 {
+		return errors.New("pick reads the lines to choose from on stdin, e.g. 'ls | tfpd pick'")
+	}
+
+	lines, readErr := readLines(os.Stdin)
+	finder := fuzzy.New(func(line string) string { return line }).
+		SetQuery(opts.query).
+		SetSelectOne(opts.selectOne).
+		SetExitZero(opts.exitZero).
+		SetMouse(opts.mouse).
+		StreamItems(lines).
+		SetStreamErr(readErr)
+	if opts.preview != "" {
+		finder.SetPreview(func(line string) string { return preview(opts.preview, line) })
+	}
+
+	var chosen []string
+	var err error
+	if opts.multi {
+		chosen, err = finder.FindMulti()
+	} else {
+		var line string
+		line, err = finder.Find()
+		chosen = []string{line}
+	}
+	if err != nil {
+		return err
+	}
+	for _, line := range chosen {
+		fmt.Println(line)
+	}
+	return nil
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 
 	"github.com/urfave/cli/v3"
+
+	"github.com/StateOfDenial/tfpd/internal/config"
+	"github.com/StateOfDenial/tfpd/internal/lookup"
 )
 
 func flags() []cli.Flag {
@@ -16,14 +19,39 @@ func flags() []cli.Flag {
 			Name:  "version",
 			Usage: "what version of the provide to search",
 		},
-		&cli.BoolFlag{
-			Name:  "data",
-			Usage: "whether to search for a data resource instead",
+		&cli.StringFlag{
+			Name:      "category",
+			Usage:     "the kind of doc to search: resources, data-sources, ephemeral-resources, functions, guides or overview",
+			Validator: lookup.CheckCategory,
 		},
 		&cli.StringFlag{
 			Name:  "resource",
 			Usage: "terraform resource name to search for",
 		},
+		&cli.BoolFlag{
+			Name:  "pager",
+			Usage: "pipe the chosen doc into $PAGER instead of the built-in viewer",
+		},
+		&cli.BoolFlag{
+			Name:  "render",
+			Usage: "with --pager, pipe the doc styled as the built-in viewer shows it rather than as raw markdown",
+		},
+		&cli.BoolFlag{
+			Name:  "edit",
+			Usage: "open the chosen doc read-only in $EDITOR",
+		},
+		&cli.BoolFlag{
+			Name:  "multi",
+			Usage: "pick several docs with Tab and open them together",
+		},
+		&cli.BoolFlag{
+			Name:  "tree",
+			Usage: "browse the docs grouped by subcategory, as on the registry website",
+		},
+		&cli.BoolFlag{
+			Name:  "stdout",
+			Usage: "print the raw markdown of the chosen docs instead of viewing them",
+		},
 	}
 }
 
@@ -37,15 +65,219 @@ func Command() *cli.Command {
 				Name:  "get-doc",
 				Usage: "gets documentation for a specific resource",
 				Action: func(ctx context.Context, cmd *cli.Command) error {
-					provider := cmd.String("provider")
-					version := cmd.String("version")
-					isData := cmd.Bool("data")
-					resource := cmd.String("resource")
+					cfg, err := config.Load()
+					if err != nil {
+						return err
+					}
+					opts := docOptions{
+						Options: lookup.Options{
+							Provider: cmd.String("provider"),
+							Version:  cmd.String("version"),
+							Resource: cmd.String("resource"),
+							Category: cmd.String("category"),
+							Multi:    cmd.Bool("multi"),
+							Tree:     cmd.Bool("tree"),
+							Mouse:    cfg.MouseEnabled(),
+						},
+						pager:  cmd.Bool("pager") || cfg.UsePager,
+						render: cmd.Bool("render") || cfg.PagerRender,
+						edit:   cmd.Bool("edit"),
+						stdout: cmd.Bool("stdout"),
+					}
+
+					return command(opts, cfg)
+				},
+			},
+			{
+				Name:      "examples",
+				Usage:     "pick an HCL example from a resource's documentation",
+				ArgsUsage: "<resource>",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "copy",
+						Usage: "copy the example to the clipboard over OSC 52 instead of printing it",
+					},
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					cfg, err := config.Load()
+					if err != nil {
+						return err
+					}
+					opts := lookup.Options{
+						Provider: cmd.String("provider"),
+						Version:  cmd.String("version"),
+						Resource: cmd.Args().First(),
+						Category: cmd.String("category"),
+						Tree:     cmd.Bool("tree"),
+						Mouse:    cfg.MouseEnabled(),
+					}
+					if opts.Resource == "" {
+						opts.Resource = cmd.String("resource")
+					}
+
+					return examplesCommand(opts, cmd.Bool("copy"))
+				},
+			},
+			{
+				Name:      "reference",
+				Usage:     "show a resource's arguments, attributes and timeouts",
+				ArgsUsage: "<resource>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "format",
+						Usage: "how to show the reference: table or json",
+						Value: "table",
+					},
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					cfg, err := config.Load()
+					if err != nil {
+						return err
+					}
+					opts := lookup.Options{
+						Provider: cmd.String("provider"),
+						Version:  cmd.String("version"),
+						Resource: cmd.Args().First(),
+						Category: cmd.String("category"),
+						Tree:     cmd.Bool("tree"),
+						Mouse:    cfg.MouseEnabled(),
+					}
+					if opts.Resource == "" {
+						opts.Resource = cmd.String("resource")
+					}
+
+					return referenceCommand(opts, cmd.String("format"))
+				},
+			},
+		},
+	}
+}
+
+func selectFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "provider",
+			Usage: "the provider to pick a version or doc of",
+		},
+		&cli.StringFlag{
+			Name:  "version",
+			Usage: "the provider version to pick a doc from",
+		},
+		&cli.StringFlag{
+			Name:      "category",
+			Usage:     "the kind of doc to search: resources, data-sources, ephemeral-resources, functions, guides or overview",
+			Validator: lookup.CheckCategory,
+		},
+		&cli.StringFlag{
+			Name:  "query",
+			Usage: "the search to start the finder with",
+		},
+		&cli.StringFlag{
+			Name:  "format",
+			Usage: "how to print the selection: text or json",
+			Value: "text",
+		},
+	}
+}
+
+func SelectCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "select",
+		Usage: "run one step of get-doc and print what was picked, registry IDs included",
+		Flags: selectFlags(),
+		Commands: []*cli.Command{
+			{
+				Name:  "provider",
+				Usage: "pick a provider from the lock file",
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					cfg, err := config.Load()
+					if err != nil {
+						return err
+					}
+					return selectProvider(cmd.String("query"), cmd.String("format"), cfg.MouseEnabled())
+				},
+			},
+			{
+				Name:  "version",
+				Usage: "pick a version of --provider",
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					cfg, err := config.Load()
+					if err != nil {
+						return err
+					}
+					return selectVersion(cmd.String("provider"), cmd.String("query"), cmd.String("format"), cfg.MouseEnabled())
+				},
+			},
+			{
+				Name:  "doc",
+				Usage: "pick a doc from --version of --provider",
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					cfg, err := config.Load()
+					if err != nil {
+						return err
+					}
+					opts := lookup.Options{
+						Provider: cmd.String("provider"),
+						Version:  cmd.String("version"),
+						Resource: cmd.String("query"),
+						Category: cmd.String("category"),
+						Mouse:    cfg.MouseEnabled(),
+					}
 
-					command(provider, version, resource, isData)
-					return nil
+					return selectDoc(opts, cmd.String("format"))
 				},
 			},
 		},
 	}
 }
+
+func HistoryCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "history",
+		Usage: "reopen a recently viewed doc",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "list",
+				Usage: "print the recently viewed docs, newest first, instead of picking one",
+			},
+			&cli.BoolFlag{
+				Name:  "pager",
+				Usage: "pipe the chosen doc into $PAGER instead of the built-in viewer",
+			},
+			&cli.BoolFlag{
+				Name:  "render",
+				Usage: "with --pager, pipe the doc styled as the built-in viewer shows it rather than as raw markdown",
+			},
+			&cli.BoolFlag{
+				Name:  "edit",
+				Usage: "open the chosen doc read-only in $EDITOR",
+			},
+			&cli.BoolFlag{
+				Name:  "multi",
+				Usage: "pick several docs with Tab and open them together",
+			},
+			&cli.BoolFlag{
+				Name:  "stdout",
+				Usage: "print the raw markdown of the chosen docs instead of viewing them",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+			opts := docOptions{
+				Options: lookup.Options{
+					Multi: cmd.Bool("multi"),
+					Mouse: cfg.MouseEnabled(),
+				},
+				pager:  cmd.Bool("pager") || cfg.UsePager,
+				render: cmd.Bool("render") || cfg.PagerRender,
+				edit:   cmd.Bool("edit"),
+				stdout: cmd.Bool("stdout"),
+			}
+
+			return historyCommand(opts, cmd.Bool("list"), cfg)
+		},
+	}
+}
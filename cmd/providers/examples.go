@@ -0,0 +1,41 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/StateOfDenial/tfpd/internal/clipboard"
+	"github.com/StateOfDenial/tfpd/internal/docs"
+	"github.com/StateOfDenial/tfpd/internal/lookup"
+	"github.com/StateOfDenial/tfpd/pkg/fuzzy"
+)
+
+func pickExample(examples []docs.Example, mouse bool) (docs.Example, error) {
+	return fuzzy.New(docs.Example.String).SetSelectOne(true).SetMouse(mouse).SetItems(examples).Find()
+}
+
+func examplesCommand(opts lookup.Options, copy bool) error {
+	hashiClient := lookup.NewClient()
+	chosen, err := lookup.FindDoc(opts, hashiClient)
+	if err != nil {
+		return err
+	}
+	content, err := hashiClient.GetResourceDoc(chosen.Id)
+	if err != nil {
+		return err
+	}
+	examples := docs.Examples(content)
+	if len(examples) == 0 {
+		return errors.New("no terraform examples found in " + chosen.String())
+	}
+
+	example, err := pickExample(examples, opts.Mouse)
+	if err != nil {
+		return err
+	}
+	if copy {
+		return clipboard.Copy(example.Code)
+	}
+	fmt.Println(example.Code)
+	return nil
+}
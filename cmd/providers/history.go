@@ -0,0 +1,68 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/StateOfDenial/tfpd/internal/config"
+	h "github.com/StateOfDenial/tfpd/internal/hashicorp"
+	"github.com/StateOfDenial/tfpd/internal/history"
+	"github.com/StateOfDenial/tfpd/internal/lookup"
+	"github.com/StateOfDenial/tfpd/pkg/fuzzy"
+)
+
+func historyFields(e history.Entry) []string {
+	return []string{e.Slug, e.Category, e.Provider, e.Version}
+}
+
+func historyCommand(opts docOptions, list bool, cfg config.Config) error {
+	hist, err := history.Load()
+	if err != nil {
+		return err
+	}
+	recent := hist.Recent()
+	if len(recent) == 0 {
+		return errors.New("no docs have been opened yet")
+	}
+	if list {
+		for _, e := range recent {
+			fields := append([]string{e.Time.Local().Format(time.DateTime)}, historyFields(e)...)
+			fmt.Println(strings.Join(fields, "\t"))
+		}
+		return nil
+	}
+
+	hashiClient := lookup.NewClient()
+	finder := fuzzy.New[history.Entry](nil).
+		SetColumns(historyFields, 0, 1, 2).
+		SetMouse(opts.Mouse).
+		SetItems(recent).
+		SetMarkdownPreview(func(e history.Entry) string { return lookup.DocPreview(hashiClient, e.Id) })
+	var picked []history.Entry
+	if opts.Multi {
+		picked, err = finder.FindMulti()
+	} else {
+		var e history.Entry
+		e, err = finder.Find()
+		picked = []history.Entry{e}
+	}
+	if err != nil {
+		return err
+	}
+
+	chosen := make([]h.Resource, len(picked))
+	for i, e := range picked {
+		chosen[i].Id = e.Id
+		chosen[i].Attributes.Category = e.Category
+		chosen[i].Attributes.Slug = e.Slug
+		e.Query, e.Time = "", time.Now()
+		hist.Add(e)
+	}
+	if err := hist.Save(); err != nil {
+		fmt.Fprintln(os.Stderr, "warning: "+err.Error())
+	}
+	return showDocs(hashiClient, chosen, opts, cfg)
+}
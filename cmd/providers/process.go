@@ -1,110 +1,81 @@
 package providers
 
 import (
-	"errors"
 	"fmt"
-	// "os"
+	"os"
+	"strings"
 
+	"golang.org/x/term"
+
+	"github.com/StateOfDenial/tfpd/internal/config"
 	h "github.com/StateOfDenial/tfpd/internal/hashicorp"
+	"github.com/StateOfDenial/tfpd/internal/lookup"
+	"github.com/StateOfDenial/tfpd/internal/pager"
 	"github.com/StateOfDenial/tfpd/internal/tui"
 )
 
-func filter[T any](ss []T, test func(T) bool) (ret []T) {
-	for _, s := range ss {
-		if test(s) {
-			ret = append(ret, s)
-		}
-	}
-	return ret
-}
-
-func promptForInput(prompt string) string {
-	var ret string
-	fmt.Println(prompt)
-	fmt.Scanln(&ret)
-	return ret
+// docOptions are the lookup options plus where to show the docs found.
+type docOptions struct {
+	lookup.Options
+	pager  bool
+	render bool
+	edit   bool
+	stdout bool
 }
 
-func processLockFile() ([]h.TerraformProvider, error) {
-	var lockProviders []h.TerraformProvider
-	lockFile, err := h.DiscoverLockFile()
-	if err != nil {
-		return lockProviders, errors.New("Could not find a lock file")
+func command(opts docOptions, cfg config.Config) error {
+	hashiClient := lookup.NewClient()
+	if !opts.stdout && !opts.edit && !opts.pager {
+		return lookup.View(opts.Options, hashiClient)
 	}
-	lockProviders = h.GetProvidersFromLockFile(lockFile)
-	if len(lockProviders) == 0 {
-		return lockProviders, errors.New("Found no searchable providers in lock file")
+	chosen, err := lookup.FindDocs(opts.Options, hashiClient)
+	if err != nil {
+		return err
 	}
-	return lockProviders, nil
+	return showDocs(hashiClient, chosen, opts, cfg)
 }
 
-func processProviders(providers []h.TerraformProvider, initProvider string) (string, string, error) {
-	switch {
-	case len(providers) > 1:
-		provs := make([]string, len(providers))
-		for i := range providers {
-			provs[i] = providers[i].Name + " : " + providers[i].Version
-		}
-		ff := tui.NewFuzzyFinder()
-		ff.SetFuzzyItems(provs)
-		providerIdx := ff.FuzzyFindWithInput(initProvider)
-		return providers[providerIdx].Name, providers[providerIdx].Version, nil
-	case len(providers) == 1:
-		return providers[0].Name, providers[0].Version, nil
-	default:
-		return "", "", errors.New("No providers list to process")
+// terminalWidth is how wide stdout's terminal is, or 80 columns if it isn't
+// one.
+func terminalWidth() int {
+	if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && w > 0 {
+		return w
 	}
+	return 80
 }
 
-func command(provider, version, resource string, isData bool) error {
-	var providerName, providerVersion string
-	hashiClient := h.NewClient().SetBaseUrl("http://registry.terraform.io/v2/")
-	providers, err := processLockFile()
+// showDocs opens chosen however opts asks: printed, in an editor or pager,
+// or in the built-in viewer.
+func showDocs(hashiClient *h.Client, chosen []h.Resource, opts docOptions, cfg config.Config) error {
+	tabs, err := lookup.DocTabs(hashiClient, chosen)
 	if err != nil {
-		if provider == "" {
-			fmt.Println()
-			providerName = promptForInput("Enter in a provider to look for: e.g. 'hashicorp/google'")
-		} else {
-			providerName = provider
-		}
-	} else {
-		providerName, providerVersion, err = processProviders(providers, provider)
-		if err != nil {
-			panic("tried to process 0 providers from lock file, shouldn't happen")
-		}
+		return err
 	}
-
-	versions := hashiClient.GetProviderVersions(providerName)
-	var providerVersionResources h.ProviderVersionRes
-	if providerVersion == "" {
-		vers := make([]string, len(versions.Included))
-		for i := range versions.Included {
-			vers[i] = versions.Included[i].String()
-		}
-		ff := tui.NewFuzzyFinder()
-		ff.SetFuzzyItems(vers)
-		versionIdx := ff.FuzzyFindWithInput(version)
-		providerVersionResources = hashiClient.GetProviderVersionResources(versions.Included[versionIdx].Id)
-	} else {
-		filterTest := func(v h.Version) bool { return v.Attributes.Version == providerVersion }
-		version := filter(versions.Included, filterTest)
-		providerVersionResources = hashiClient.GetProviderVersionResources(version[0].Id)
+	contents := make([]string, len(tabs))
+	for i, t := range tabs {
+		contents[i] = t.Content
 	}
+	doc := strings.Join(contents, "\n")
 
-	resources := make([]string, len(providerVersionResources.Included))
-	for i := range providerVersionResources.Included {
-		resources[i] = providerVersionResources.Included[i].String()
+	switch {
+	case opts.stdout:
+		_, err := fmt.Print(doc)
+		return err
+	case opts.edit:
+		name := chosen[0].Attributes.Slug
+		if len(chosen) > 1 {
+			name = "docs"
+		}
+		return pager.Edit(doc, name, cfg.Editor())
+	case opts.pager:
+		if opts.render {
+			doc = tui.RenderANSI(doc, terminalWidth())
+		}
+		return pager.Page(doc, cfg.Pager())
 	}
-	ff := tui.NewFuzzyFinder()
-	ff.SetFuzzyItems(resources)
-	var resourceIdx int
-	if isData {
-		resourceIdx = ff.FuzzyFindWithInput("data-sources: " + resource)
-	} else {
-		resourceIdx = ff.FuzzyFindWithInput(resource)
+	m, err := tui.NewMDViewerTabs(tabs)
+	if err != nil {
+		return err
 	}
-
-	m := tui.NewMDViewer(hashiClient.GetResourceDoc(providerVersionResources.Included[resourceIdx].Id))
-	m.Display()
-	return nil
+	return m.SetMouse(opts.Mouse).Display()
 }
@@ -0,0 +1,45 @@
+package providers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/StateOfDenial/tfpd/internal/docs"
+	"github.com/StateOfDenial/tfpd/internal/lookup"
+	"github.com/StateOfDenial/tfpd/internal/tui"
+)
+
+func referenceCommand(opts lookup.Options, format string) error {
+	if format != "table" && format != "json" {
+		return errors.New("unknown format " + format + ", expected table or json")
+	}
+	hashiClient := lookup.NewClient()
+	chosen, err := lookup.FindDoc(opts, hashiClient)
+	if err != nil {
+		return err
+	}
+	content, err := hashiClient.GetResourceDoc(chosen.Id)
+	if err != nil {
+		return err
+	}
+	ref := docs.ParseReference(content)
+	if len(ref.Fields) == 0 {
+		return errors.New("no argument or attribute reference found in " + chosen.String())
+	}
+
+	if format == "json" {
+		out, err := json.MarshalIndent(ref, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+	t, err := tui.NewTable(ref.Table())
+	if err != nil {
+		return err
+	}
+	t.SetMouse(opts.Mouse).Display()
+	return nil
+}
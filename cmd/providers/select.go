@@ -0,0 +1,121 @@
+package providers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	h "github.com/StateOfDenial/tfpd/internal/hashicorp"
+	"github.com/StateOfDenial/tfpd/internal/lookup"
+)
+
+type providerSelection struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Id      string `json:"id"`
+}
+
+type versionSelection struct {
+	Provider string `json:"provider"`
+	Version  string `json:"version"`
+	Id       string `json:"id"`
+}
+
+type docSelection struct {
+	Provider  string `json:"provider"`
+	Version   string `json:"version"`
+	VersionId string `json:"versionId"`
+	Category  string `json:"category"`
+	Slug      string `json:"slug"`
+	Id        string `json:"id"`
+}
+
+func checkFormat(format string) error {
+	if format != "text" && format != "json" {
+		return errors.New("unknown format " + format + ", expected text or json")
+	}
+	return nil
+}
+
+// printSelection writes v as JSON, or fields tab-separated on one line for
+// the text format.
+func printSelection(format string, v any, fields ...string) error {
+	if format == "json" {
+		out, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+	fmt.Println(strings.Join(fields, "\t"))
+	return nil
+}
+
+func selectProvider(query, format string, mouse bool) error {
+	if err := checkFormat(format); err != nil {
+		return err
+	}
+	providers, err := lookup.LockedProviders()
+	if err != nil {
+		return err
+	}
+	name, version, err := lookup.PickProvider(providers, query, mouse)
+	if err != nil {
+		return err
+	}
+	id, err := lookup.NewClient().GetProviderId(name)
+	if err != nil {
+		return err
+	}
+	return printSelection(format, providerSelection{Name: name, Version: version, Id: id}, name, version, id)
+}
+
+func selectVersion(provider, query, format string, mouse bool) error {
+	if err := checkFormat(format); err != nil {
+		return err
+	}
+	if provider == "" {
+		return errors.New("a provider is required, e.g. 'tfpd select version --provider hashicorp/aws'")
+	}
+	v, err := lookup.PickVersion(lookup.NewClient(), provider, query, mouse)
+	if err != nil {
+		return err
+	}
+	return printSelection(format, versionSelection{Provider: provider, Version: v.String(), Id: v.Id},
+		provider, v.String(), v.Id)
+}
+
+func selectDoc(opts lookup.Options, format string) error {
+	if err := checkFormat(format); err != nil {
+		return err
+	}
+	if opts.Provider == "" {
+		return errors.New("a provider is required, e.g. 'tfpd select doc --provider hashicorp/aws --version 5.31.0'")
+	}
+	hashiClient := lookup.NewClient()
+	var v h.Version
+	var err error
+	if opts.Version == "" {
+		v, err = lookup.PickVersion(hashiClient, opts.Provider, "", opts.Mouse)
+	} else {
+		v, err = lookup.MatchVersion(hashiClient, opts.Provider, opts.Version)
+	}
+	if err != nil {
+		return err
+	}
+	chosen, err := lookup.PickDocs(hashiClient, opts.Provider, v, opts)
+	if err != nil {
+		return err
+	}
+	r := chosen[0]
+	return printSelection(format, docSelection{
+		Provider:  opts.Provider,
+		Version:   v.String(),
+		VersionId: v.Id,
+		Category:  r.Attributes.Category,
+		Slug:      r.Attributes.Slug,
+		Id:        r.Id,
+	}, opts.Provider, v.String(), v.Id, r.Attributes.Category, r.Attributes.Slug, r.Id)
+}
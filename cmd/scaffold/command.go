@@ -0,0 +1,58 @@
+package scaffold
+
+import (
+	"context"
+	"errors"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/StateOfDenial/tfpd/internal/config"
+)
+
+func flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "provider",
+			Usage: "the provider the resource belongs to",
+		},
+		&cli.StringFlag{
+			Name:  "version",
+			Usage: "what version of the provider to read the docs from",
+		},
+		&cli.StringFlag{
+			Name:  "name",
+			Usage: "the local name to give the block",
+			Value: "this",
+		},
+		&cli.BoolFlag{
+			Name:  "data",
+			Usage: "scaffold a data source instead of a resource",
+		},
+		&cli.StringFlag{
+			Name:  "out",
+			Usage: "a .tf file to append the block to instead of printing it",
+		},
+	}
+}
+
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:      "scaffold",
+		Usage:     "generate an HCL skeleton from a resource's Argument Reference",
+		ArgsUsage: "<resource_type>",
+		Flags:     flags(),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			resourceType := cmd.Args().First()
+			if resourceType == "" {
+				return errors.New("a resource type is required, e.g. 'tfpd scaffold aws_lb_listener'")
+			}
+
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+
+			return command(resourceType, cmd.String("name"), cmd.String("provider"), cmd.String("version"), cmd.String("out"), cmd.Bool("data"), cfg.MouseEnabled())
+		},
+	}
+}
@@ -0,0 +1,56 @@
+package scaffold
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/StateOfDenial/tfpd/internal/docs"
+	"github.com/StateOfDenial/tfpd/internal/hclgen"
+	"github.com/StateOfDenial/tfpd/internal/lookup"
+)
+
+func appendToFile(path, content string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if info, err := f.Stat(); err == nil && info.Size() > 0 {
+		content = "\n" + content
+	}
+	_, err = f.WriteString(content)
+	return err
+}
+
+func command(resourceType, name, provider, version, out string, isData, mouse bool) error {
+	category := "resources"
+	if isData {
+		category = "data-sources"
+	}
+	chosen, content, err := lookup.GetDoc(lookup.Options{
+		Provider: provider,
+		Version:  version,
+		Resource: resourceType,
+		Category: category,
+		Mouse:    mouse,
+	})
+	if err != nil {
+		return err
+	}
+	args := docs.ArgumentReference(content)
+	if len(args.Arguments) == 0 && len(args.Blocks) == 0 {
+		return errors.New("could not find an Argument Reference in the docs for " + resourceType)
+	}
+
+	keyword := "resource"
+	if chosen.Attributes.Category == "data-sources" {
+		keyword = "data"
+	}
+	hcl := hclgen.Scaffold(keyword, resourceType, name, args)
+	if out == "" {
+		fmt.Print(hcl)
+		return nil
+	}
+	return appendToFile(out, hcl)
+}
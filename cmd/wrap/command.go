@@ -0,0 +1,54 @@
+package wrap
+
+import (
+	"context"
+	"errors"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/StateOfDenial/tfpd/internal/config"
+)
+
+func flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "provider",
+			Usage: "the provider the resource belongs to",
+		},
+		&cli.StringFlag{
+			Name:  "version",
+			Usage: "what version of the provider to read the docs from",
+		},
+		&cli.StringFlag{
+			Name:  "out",
+			Usage: "the directory to write the module into",
+			Value: ".",
+		},
+		&cli.BoolFlag{
+			Name:  "force",
+			Usage: "overwrite existing variables.tf, main.tf and outputs.tf files",
+		},
+	}
+}
+
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:      "wrap",
+		Usage:     "generate a module wrapping a resource from its Argument and Attributes Reference",
+		ArgsUsage: "<resource_type>",
+		Flags:     flags(),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			resourceType := cmd.Args().First()
+			if resourceType == "" {
+				return errors.New("a resource type is required, e.g. 'tfpd wrap aws_lb_listener --out modules/listener'")
+			}
+
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+
+			return command(resourceType, cmd.String("provider"), cmd.String("version"), cmd.String("out"), cmd.Bool("force"), cfg.MouseEnabled())
+		},
+	}
+}
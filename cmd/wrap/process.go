@@ -0,0 +1,65 @@
+package wrap
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/StateOfDenial/tfpd/internal/docs"
+	"github.com/StateOfDenial/tfpd/internal/hclgen"
+	"github.com/StateOfDenial/tfpd/internal/lookup"
+)
+
+// writeFiles writes files into dir in name order, so what's printed doesn't
+// change from run to run.
+func writeFiles(dir string, files map[string]string, force bool) error {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if !force {
+		for _, name := range names {
+			if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+				return errors.New(filepath.Join(dir, name) + " already exists, use --force to overwrite it")
+			}
+		}
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(files[name]), 0644); err != nil {
+			return err
+		}
+		fmt.Println("wrote " + path)
+	}
+	return nil
+}
+
+func command(resourceType, provider, version, out string, force, mouse bool) error {
+	_, content, err := lookup.GetDoc(lookup.Options{
+		Provider: provider,
+		Version:  version,
+		Resource: resourceType,
+		Category: "resources",
+		Mouse:    mouse,
+	})
+	if err != nil {
+		return err
+	}
+	ref := docs.ParseReference(content)
+	if len(ref.Kind(docs.KindArgument)) == 0 {
+		return errors.New("could not find an Argument Reference in the docs for " + resourceType)
+	}
+
+	module := hclgen.Wrap(resourceType, ref)
+	return writeFiles(out, map[string]string{
+		"variables.tf": module.Variables,
+		"main.tf":      module.Main,
+		"outputs.tf":   module.Outputs,
+	}, force)
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const defaultPager = "less -R"
+const defaultEditor = "vi"
+
+type Config struct {
+	UsePager      bool   `json:"pager"`
+	PagerCommand  string `json:"pagerCommand"`
+	PagerRender   bool   `json:"pagerRender"`
+	EditorCommand string `json:"editorCommand"`
+	Mouse         *bool  `json:"mouse"`
+}
+
+func Path() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "tfpd", "config.json"), nil
+}
+
+func Load() (Config, error) {
+	var c Config
+	path, err := Path()
+	if err != nil {
+		return c, err
+	}
+	body, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return c, err
+	}
+	if err := json.Unmarshal(body, &c); err != nil {
+		return c, errors.New("could not parse config file " + path + ": " + err.Error())
+	}
+	return c, nil
+}
+
+func (c Config) Pager() string {
+	if c.PagerCommand != "" {
+		return c.PagerCommand
+	}
+	if p := os.Getenv("PAGER"); p != "" {
+		return p
+	}
+	return defaultPager
+}
+
+// Editor is editorCommand if it's set, then $EDITOR, then vi, the same
+// order Pager takes.
+func (c Config) Editor() string {
+	if c.EditorCommand != "" {
+		return c.EditorCommand
+	}
+	if e := os.Getenv("EDITOR"); e != "" {
+		return e
+	}
+	return defaultEditor
+}
+
+// MouseEnabled is whether the finder and viewer should take the mouse,
+// which they do unless "mouse" is set to false.
+func (c Config) MouseEnabled() bool {
+	return c.Mouse == nil || *c.Mouse
+}
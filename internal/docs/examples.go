@@ -0,0 +1,85 @@
+package docs
+
+import (
+	"strings"
+)
+
+type Example struct {
+	Heading  string
+	Language string
+	Code     string
+}
+
+func (e Example) String() string {
+	first := ""
+	for _, l := range strings.Split(e.Code, "\n") {
+		if strings.TrimSpace(l) != "" {
+			first = strings.TrimSpace(l)
+			break
+		}
+	}
+	return e.Heading + ": " + first
+}
+
+func isExampleLanguage(lang string) bool {
+	switch strings.ToLower(lang) {
+	case "terraform", "hcl", "tf":
+		return true
+	}
+	return false
+}
+
+func fence(line string) (string, bool) {
+	trimmed := strings.TrimSpace(line)
+	for _, f := range []string{"```", "~~~"} {
+		if strings.HasPrefix(trimmed, f) {
+			return f, true
+		}
+	}
+	return "", false
+}
+
+func headingText(line string) (string, bool) {
+	if !strings.HasPrefix(line, "#") {
+		return "", false
+	}
+	text := strings.TrimLeft(line, "#")
+	if text != "" && text[0] != ' ' {
+		return "", false
+	}
+	return strings.TrimSpace(text), true
+}
+
+func Examples(content string) []Example {
+	var examples []Example
+	var heading, openFence, lang string
+	var code []string
+
+	for _, l := range strings.Split(content, "\n") {
+		if openFence != "" {
+			if strings.TrimSpace(l) == openFence {
+				if isExampleLanguage(lang) {
+					examples = append(examples, Example{
+						Heading:  heading,
+						Language: lang,
+						Code:     strings.Join(code, "\n"),
+					})
+				}
+				openFence, code = "", nil
+				continue
+			}
+			code = append(code, l)
+			continue
+		}
+
+		if f, ok := fence(l); ok {
+			openFence = f
+			lang = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(l), f))
+			continue
+		}
+		if h, ok := headingText(l); ok {
+			heading = h
+		}
+	}
+	return examples
+}
@@ -0,0 +1,114 @@
+package docs
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+type ImportSpec struct {
+	Description string
+	Examples    []string
+}
+
+var importCommandRegex = regexp.MustCompile(`terraform import\s+(?:'[^']*'|"[^"]*"|\S+)\s+(.+)$`)
+var importIdRegex = regexp.MustCompile(`^\s*id\s*=\s*"(.*)"\s*$`)
+var idPrefixRegex = regexp.MustCompile(`^([a-z]+-)[0-9a-f]+$`)
+
+func unquote(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && (s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+func (s *ImportSpec) addExample(id string) {
+	for _, e := range s.Examples {
+		if e == id {
+			return
+		}
+	}
+	s.Examples = append(s.Examples, id)
+}
+
+func ParseImport(content string) ImportSpec {
+	var spec ImportSpec
+	var prose []string
+	sectionLevel := 0
+	openFence := ""
+
+	for _, l := range strings.Split(content, "\n") {
+		if openFence != "" {
+			if strings.TrimSpace(l) == openFence {
+				openFence = ""
+				continue
+			}
+			if sectionLevel == 0 {
+				continue
+			}
+			if m := importCommandRegex.FindStringSubmatch(l); m != nil {
+				spec.addExample(unquote(m[1]))
+			} else if m := importIdRegex.FindStringSubmatch(l); m != nil {
+				spec.addExample(m[1])
+			}
+			continue
+		}
+		if f, ok := fence(l); ok {
+			openFence = f
+			continue
+		}
+
+		if text, ok := headingText(l); ok {
+			level := headingLevel(l)
+			if isReferenceHeading(text, "import") {
+				sectionLevel = level
+				continue
+			}
+			if level <= sectionLevel {
+				sectionLevel = 0
+			}
+			continue
+		}
+		if sectionLevel != 0 && strings.TrimSpace(l) != "" {
+			prose = append(prose, strings.TrimSpace(l))
+		}
+	}
+	spec.Description = strings.Join(prose, "\n")
+	return spec
+}
+
+func idShape(id string) string {
+	var shape []rune
+	for _, r := range id {
+		if strings.ContainsRune("/,:|@", r) {
+			shape = append(shape, r)
+		}
+	}
+	return string(shape)
+}
+
+func matchesExample(id, example string) bool {
+	if idShape(id) != idShape(example) {
+		return false
+	}
+	if m := idPrefixRegex.FindStringSubmatch(example); m != nil && !strings.HasPrefix(id, m[1]) {
+		return false
+	}
+	if strings.HasPrefix(example, "arn:") != strings.HasPrefix(id, "arn:") {
+		return false
+	}
+	return true
+}
+
+func (s ImportSpec) Check(id string) error {
+	if len(s.Examples) == 0 {
+		return nil
+	}
+	for _, example := range s.Examples {
+		if matchesExample(id, example) {
+			return nil
+		}
+	}
+	return errors.New("id " + id + " does not look like the documented format, e.g. " + strings.Join(s.Examples, " or "))
+}
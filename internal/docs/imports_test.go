@@ -0,0 +1,133 @@
+package docs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const listenerArn = "arn:aws:elasticloadbalancing:us-west-2:187416307283:listener/app/front-end-alb/8e4497da625e2d8a/9ab28ade35828f96"
+
+func TestParseImport(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		description string
+		examples    []string
+	}{
+		{
+			name: "import block and terraform import of the same id",
+			content: `## Example Usage
+
+` + "```terraform" + `
+resource "aws_lb_listener" "front_end" {
+  id = "not-an-import"
+}
+` + "```" + `
+
+## Import
+
+In Terraform v1.5.0 and later, use an import block to import listeners using their ARN. For example:
+
+` + "```terraform" + `
+import {
+  to = aws_lb_listener.front_end
+  id = "` + listenerArn + `"
+}
+` + "```" + `
+
+Using ` + "`terraform import`" + `, import listeners using their ARN. For example:
+
+` + "```console" + `
+% terraform import aws_lb_listener.front_end ` + listenerArn + `
+` + "```",
+			description: "In Terraform v1.5.0 and later, use an import block to import listeners using their ARN. For example:\n" +
+				"Using `terraform import`, import listeners using their ARN. For example:",
+			examples: []string{listenerArn},
+		},
+		{
+			name: "quoted terraform import forms",
+			content: `## Import
+
+Job can be imported using any of these accepted formats:
+
+` + "```" + `
+$ terraform import 'google_cloud_run_v2_job.default' "projects/{{project}}/locations/{{location}}/jobs/{{name}}"
+$ terraform import google_cloud_run_v2_job.default '{{location}}/{{name}}'
+$ terraform import google_cloud_run_v2_job.default {{name}}
+` + "```",
+			description: "Job can be imported using any of these accepted formats:",
+			examples: []string{
+				"projects/{{project}}/locations/{{location}}/jobs/{{name}}",
+				"{{location}}/{{name}}",
+				"{{name}}",
+			},
+		},
+		{
+			name: "section ends at the next heading",
+			content: `## Import
+
+Instances can be imported using the ` + "`id`" + `, e.g.,
+
+` + "```" + `
+$ terraform import aws_instance.web i-12345678
+` + "```" + `
+
+## Notes
+
+` + "```" + `
+$ terraform import aws_instance.other i-87654321
+` + "```",
+			description: "Instances can be imported using the `id`, e.g.,",
+			examples:    []string{"i-12345678"},
+		},
+		{
+			name:    "no import section",
+			content: "# aws_lambda_invocation\n\n## Argument Reference\n\n* `function_name` - (Required) Name of the lambda function.\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := ParseImport(tt.content)
+			if spec.Description != tt.description {
+				t.Errorf("got description %q, want %q", spec.Description, tt.description)
+			}
+			if !reflect.DeepEqual(spec.Examples, tt.examples) {
+				t.Errorf("got examples %q, want %q", spec.Examples, tt.examples)
+			}
+		})
+	}
+}
+
+func TestImportSpecCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		examples []string
+		id       string
+		wantErr  bool
+	}{
+		{"nothing documented accepts anything", nil, "whatever/it:is", false},
+		{"same prefix", []string{"i-12345678"}, "i-0abcdef1234567890", false},
+		{"other prefix", []string{"i-12345678"}, "vol-12345678", true},
+		{"prefix but extra separators", []string{"i-12345678"}, "i-1234/5678", true},
+		{"arn of the same shape", []string{listenerArn}, "arn:aws:elasticloadbalancing:eu-west-1:123456789012:listener/app/alb/1/2", false},
+		{"name instead of arn", []string{listenerArn}, "front-end-alb", true},
+		{"arn shape without arn prefix", []string{"arn:aws:s3:::bucket"}, "aws:s3:x:::bucket", true},
+		{"id where arn isn't documented", []string{"a:b:c:d:e:f"}, "arn:b:c:d:e:f", true},
+		{"template shape", []string{"projects/{{project}}/locations/{{location}}"}, "projects/p/locations/l", false},
+		{"template shape too short", []string{"projects/{{project}}/locations/{{location}}"}, "p/l", true},
+		{"any of several formats", []string{"{{project}}/{{name}}", "{{name}}"}, "job", false},
+		{"none of several formats", []string{"{{project}}/{{name}}", "{{name}}"}, "a:b", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ImportSpec{Examples: tt.examples}.Check(tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), tt.examples[0]) {
+				t.Errorf("error %q doesn't show the documented format", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,254 @@
+package docs
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ParseInline splits text into spans by its emphasis, code and links. HTML
+// tags and comments are dropped, keeping the text between tags.
+func ParseInline(text string) []Span {
+	var spans []Span
+	inline([]rune(text), 0, "", &spans)
+	return spans
+}
+
+// inline parses text in the given style, appending what it finds to spans.
+func inline(text []rune, style Style, link string, spans *[]Span) {
+	var plain []rune
+	emit := func(s string, st Style, l string) {
+		if s == "" {
+			return
+		}
+		if n := len(*spans); n > 0 && (*spans)[n-1].Style == st && (*spans)[n-1].Link == l {
+			(*spans)[n-1].Text += s
+			return
+		}
+		*spans = append(*spans, Span{Text: s, Style: st, Link: l})
+	}
+	flush := func() {
+		emit(string(plain), style, link)
+		plain = nil
+	}
+
+	for i := 0; i < len(text); i++ {
+		r := text[i]
+		switch {
+		case r == '\\' && i+1 < len(text) && (unicode.IsPunct(text[i+1]) || unicode.IsSymbol(text[i+1])):
+			plain = append(plain, text[i+1])
+			i++
+			continue
+
+		case r == '`':
+			run := runLength(text, i, '`')
+			if end := closingRun(text, i+run, '`', run); end >= 0 {
+				flush()
+				code := string(text[i+run : end])
+				if len(code) > 1 && strings.HasPrefix(code, " ") && strings.HasSuffix(code, " ") {
+					code = code[1 : len(code)-1]
+				}
+				emit(code, style|Code, link)
+				i = end + run - 1
+				continue
+			}
+			plain = append(plain, text[i:i+run]...)
+			i += run - 1
+			continue
+
+		case r == '<':
+			if end, ok := htmlEnd(text, i); ok {
+				flush()
+				tag := string(text[i : end+1])
+				if url := strings.Trim(tag, "<>"); strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "https://") {
+					emit(url, style|Link, url)
+				} else if strings.HasPrefix(strings.ToLower(tag), "<br") {
+					plain = append(plain, ' ')
+				}
+				i = end
+				continue
+			}
+
+		case r == '[' || r == '!' && i+1 < len(text) && text[i+1] == '[':
+			start := i
+			if r == '!' {
+				start++
+			}
+			if label, url, end, ok := linkAt(text, start); ok {
+				flush()
+				if len(label) == 0 && r == '!' {
+					label = []rune("image")
+				}
+				inline(label, style|Link, url, spans)
+				i = end
+				continue
+			}
+
+		case r == '*' || r == '_' || r == '~':
+			run := runLength(text, i, r)
+			n := min(run, 3)
+			if r == '~' {
+				n = 2
+			}
+			if run >= n && opens(text, i, run, r) {
+				if end := closingDelimiter(text, i+run, r, n); end >= 0 {
+					flush()
+					plain = append(plain, text[i:i+run-n]...)
+					flush()
+					inline(text[i+run:end], style|delimiterStyle(r, n), link, spans)
+					i = end + n - 1
+					continue
+				}
+			}
+			plain = append(plain, text[i:i+run]...)
+			i += run - 1
+			continue
+		}
+		plain = append(plain, r)
+	}
+	flush()
+}
+
+func delimiterStyle(r rune, n int) Style {
+	switch {
+	case r == '~':
+		return Strike
+	case n == 1:
+		return Emphasis
+	case n == 2:
+		return Strong
+	default:
+		return Strong | Emphasis
+	}
+}
+
+func runLength(text []rune, i int, r rune) int {
+	n := 0
+	for i+n < len(text) && text[i+n] == r {
+		n++
+	}
+	return n
+}
+
+// closingRun finds the next run of exactly n r's from i, skipping longer
+// or shorter ones, as code spans need.
+func closingRun(text []rune, i int, r rune, n int) int {
+	for ; i < len(text); i++ {
+		if text[i] != r {
+			continue
+		}
+		run := runLength(text, i, r)
+		if run == n {
+			return i
+		}
+		i += run - 1
+	}
+	return -1
+}
+
+// opens reports whether the run of delimiters at i can start emphasis: it
+// must be followed by text, and underscores can't open inside a word.
+func opens(text []rune, i, run int, r rune) bool {
+	after := i + run
+	if after >= len(text) || unicode.IsSpace(text[after]) {
+		return false
+	}
+	return r != '_' || i == 0 || !isWordRune(text[i-1])
+}
+
+// closingDelimiter finds where the emphasis opened with n r's ends, at a
+// run of at least n of them after text. It returns the index to close from,
+// leaving any extra delimiters in the run inside the emphasis.
+func closingDelimiter(text []rune, i int, r rune, n int) int {
+	for ; i < len(text); i++ {
+		switch text[i] {
+		case '`':
+			run := runLength(text, i, '`')
+			if end := closingRun(text, i+run, '`', run); end >= 0 {
+				i = end + run - 1
+			}
+			continue
+		case r:
+		default:
+			continue
+		}
+		run := runLength(text, i, r)
+		if run >= n && !unicode.IsSpace(text[i-1]) {
+			after := i + run
+			if r != '_' || after >= len(text) || !isWordRune(text[after]) {
+				return i + run - n
+			}
+		}
+		i += run - 1
+	}
+	return -1
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// htmlEnd finds the end of an HTML tag, comment or autolink starting at i.
+func htmlEnd(text []rune, i int) (int, bool) {
+	rest := string(text[i:])
+	if strings.HasPrefix(rest, "<!--") {
+		if end := strings.Index(rest, "-->"); end >= 0 {
+			return i + len([]rune(rest[:end+3])) - 1, true
+		}
+		return 0, false
+	}
+	if i+1 >= len(text) || !(unicode.IsLetter(text[i+1]) || text[i+1] == '/') {
+		return 0, false
+	}
+	for j := i + 1; j < len(text); j++ {
+		switch text[j] {
+		case '>':
+			return j, true
+		case '<', '\n':
+			return 0, false
+		}
+	}
+	return 0, false
+}
+
+// linkAt parses a [label](url "title") link starting at i, returning its
+// label and url and the index of its closing parenthesis.
+func linkAt(text []rune, i int) ([]rune, string, int, bool) {
+	depth := 0
+	close := -1
+	for j := i; j < len(text) && close < 0; j++ {
+		switch text[j] {
+		case '\\':
+			j++
+		case '`':
+			run := runLength(text, j, '`')
+			if end := closingRun(text, j+run, '`', run); end >= 0 {
+				j = end + run - 1
+			}
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				close = j
+			}
+		}
+	}
+	if close < 0 || close+1 >= len(text) || text[close+1] != '(' {
+		return nil, "", 0, false
+	}
+	depth = 0
+	for j := close + 1; j < len(text); j++ {
+		switch text[j] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				target := strings.TrimSpace(string(text[close+2 : j]))
+				url, _, _ := strings.Cut(target, " ")
+				return text[i+1 : close], strings.Trim(url, "<>"), j, true
+			}
+		}
+	}
+	return nil, "", 0, false
+}
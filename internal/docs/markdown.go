@@ -0,0 +1,266 @@
+package docs
+
+import (
+	"regexp"
+	"strings"
+)
+
+type NodeKind int
+
+const (
+	Paragraph NodeKind = iota
+	Heading
+	CodeBlock
+	ListItem
+	Rule
+	Table
+	FrontMatter
+)
+
+// Style is how a span of text is marked up. Styles combine, as in bold
+// inline code.
+type Style int
+
+const (
+	Strong Style = 1 << iota
+	Emphasis
+	Strike
+	Code
+	Link
+)
+
+// Span is a run of text in one style. Link is where a Link span points.
+type Span struct {
+	Text  string
+	Style Style
+	Link  string
+}
+
+// Node is a block of a markdown doc, starting on source line Line counted
+// from 0. Level is a heading's level, or for anything else how many lists
+// deep it is. Quote counts the blockquotes it's in, and Callout is "note",
+// "warning" or "danger" for the registry's ->, ~> and !> paragraphs.
+type Node struct {
+	Kind    NodeKind
+	Line    int
+	Level   int
+	Quote   int
+	Callout string
+	Marker  string
+	Spans   []Span
+	Lang    string
+	Lines   []string
+	Rows    [][][]Span
+}
+
+var listItemRegex = regexp.MustCompile(`^(\s*)([-*+]|\d{1,9}[.)])(?:(\s+)(.*))?$`)
+var ruleRegex = regexp.MustCompile(`^\s{0,3}([-*_])(?:\s*[-*_]){2,}\s*$`)
+var tableDividerRegex = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+
+var callouts = map[string]string{"->": "note", "~>": "warning", "!>": "danger"}
+
+// list is an open list item, whose text starts content columns in.
+type list struct {
+	content int
+}
+
+type markdownParser struct {
+	nodes []Node
+	lists []list
+	open  *Node
+	text  []string
+}
+
+// ParseMarkdown splits a doc into blocks of styled text. It covers what the
+// registry's docs use, not all of CommonMark.
+func ParseMarkdown(content string) []Node {
+	var p markdownParser
+	p.parse(strings.Split(content, "\n"), 0)
+	return p.nodes
+}
+
+func (p *markdownParser) parse(lines []string, offset int) {
+	for i := 0; i < len(lines); i++ {
+		l := lines[i]
+		trimmed := strings.TrimSpace(l)
+		indent := indentOf(l)
+		switch {
+		case trimmed == "":
+			p.flush()
+		case i == 0 && offset == 0 && trimmed == "---":
+			end := i + 1
+			for end < len(lines) && strings.TrimSpace(lines[end]) != "---" {
+				end++
+			}
+			p.add(Node{Kind: FrontMatter, Line: i, Lines: lines[i+1 : min(end, len(lines))]})
+			i = end
+		case strings.HasPrefix(trimmed, "<!--"):
+			p.flush()
+			for i < len(lines) && !strings.Contains(lines[i], "-->") {
+				i++
+			}
+		case p.open != nil && !startsBlock(lines, i):
+			p.text = append(p.text, trimmed)
+		default:
+			i = p.block(lines, i, offset, indent) - 1
+		}
+	}
+	p.flush()
+}
+
+// block parses the block starting at line i, returning the line after it.
+func (p *markdownParser) block(lines []string, i, offset, indent int) int {
+	l := lines[i]
+	trimmed := strings.TrimSpace(l)
+	p.flush()
+	if m := listItemRegex.FindStringSubmatch(l); m != nil && !ruleRegex.MatchString(l) {
+		p.closeLists(len(m[1]))
+		gap := len(m[3])
+		if gap == 0 || gap > 4 {
+			gap = 1
+		}
+		level := len(p.lists)
+		p.lists = append(p.lists, list{content: len(m[1]) + len(m[2]) + gap})
+		p.start(Node{Kind: ListItem, Line: offset + i, Level: level, Marker: m[2]}, m[4])
+		return i + 1
+	}
+	p.closeLists(indent)
+	level := len(p.lists)
+
+	if f, ok := fence(l); ok {
+		n := Node{Kind: CodeBlock, Line: offset + i, Level: level, Lang: strings.TrimSpace(strings.TrimPrefix(trimmed, f))}
+		i++
+		for ; i < len(lines) && strings.TrimSpace(lines[i]) != f; i++ {
+			n.Lines = append(n.Lines, trimIndent(lines[i], indent))
+		}
+		p.add(n)
+		return i + 1
+	}
+	if text, ok := headingText(trimmed); ok {
+		p.lists = nil
+		p.add(Node{Kind: Heading, Line: offset + i, Level: headingLevel(trimmed), Spans: ParseInline(text)})
+		return i + 1
+	}
+	if ruleRegex.MatchString(l) {
+		p.lists = nil
+		p.add(Node{Kind: Rule, Line: offset + i})
+		return i + 1
+	}
+	if strings.HasPrefix(trimmed, ">") {
+		var quoted []string
+		end := i
+		for ; end < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[end]), ">"); end++ {
+			q := strings.TrimPrefix(strings.TrimSpace(lines[end]), ">")
+			quoted = append(quoted, strings.TrimPrefix(q, " "))
+		}
+		var inner markdownParser
+		inner.parse(quoted, offset+i)
+		for _, n := range inner.nodes {
+			n.Quote++
+			n.Level += level
+			p.add(n)
+		}
+		return end
+	}
+	if c, ok := callouts[trimmed[:min(2, len(trimmed))]]; ok {
+		p.start(Node{Kind: Paragraph, Line: offset + i, Level: level, Quote: 1, Callout: c}, trimmed[2:])
+		return i + 1
+	}
+	if isTableStart(lines, i) {
+		n := Node{Kind: Table, Line: offset + i, Level: level}
+		end := i
+		for ; end < len(lines) && strings.Contains(lines[end], "|"); end++ {
+			if end != i+1 {
+				n.Rows = append(n.Rows, tableCells(lines[end]))
+			}
+		}
+		p.add(n)
+		return end
+	}
+	p.start(Node{Kind: Paragraph, Line: offset + i, Level: level}, trimmed)
+	return i + 1
+}
+
+// startsBlock reports whether line i begins a new block rather than carrying
+// on the paragraph before it.
+func startsBlock(lines []string, i int) bool {
+	trimmed := strings.TrimSpace(lines[i])
+	if _, ok := fence(lines[i]); ok {
+		return true
+	}
+	if _, ok := headingText(trimmed); ok {
+		return true
+	}
+	if _, ok := callouts[trimmed[:min(2, len(trimmed))]]; ok {
+		return true
+	}
+	return listItemRegex.MatchString(lines[i]) || ruleRegex.MatchString(lines[i]) ||
+		strings.HasPrefix(trimmed, ">") || isTableStart(lines, i)
+}
+
+func isTableStart(lines []string, i int) bool {
+	return strings.Contains(lines[i], "|") && i+1 < len(lines) &&
+		strings.Contains(lines[i+1], "-") && tableDividerRegex.MatchString(lines[i+1])
+}
+
+func tableCells(line string) [][]Span {
+	line = strings.TrimSpace(line)
+	line = strings.TrimSuffix(strings.TrimPrefix(line, "|"), "|")
+	var cells [][]Span
+	for _, c := range strings.Split(line, "|") {
+		cells = append(cells, ParseInline(strings.TrimSpace(c)))
+	}
+	return cells
+}
+
+// closeLists ends the list items that a line indent columns in falls
+// outside of.
+func (p *markdownParser) closeLists(indent int) {
+	for len(p.lists) > 0 && indent < p.lists[len(p.lists)-1].content {
+		p.lists = p.lists[:len(p.lists)-1]
+	}
+}
+
+// start opens a block whose text can carry on over the following lines.
+func (p *markdownParser) start(n Node, text string) {
+	p.add(n)
+	p.open = &p.nodes[len(p.nodes)-1]
+	p.text = []string{strings.TrimSpace(text)}
+}
+
+func (p *markdownParser) add(n Node) {
+	p.flush()
+	p.nodes = append(p.nodes, n)
+}
+
+// flush gives the open block its text, now that it's all been read.
+func (p *markdownParser) flush() {
+	if p.open != nil {
+		p.open.Spans = ParseInline(strings.Join(p.text, " "))
+	}
+	p.open, p.text = nil, nil
+}
+
+func indentOf(line string) int {
+	n := 0
+	for _, r := range line {
+		switch r {
+		case ' ':
+			n++
+		case '\t':
+			n += 4
+		default:
+			return n
+		}
+	}
+	return n
+}
+
+// trimIndent takes up to indent columns of leading space off line.
+func trimIndent(line string, indent int) string {
+	i := 0
+	for i < len(line) && i < indent && line[i] == ' ' {
+		i++
+	}
+	return line[i:]
+}
@@ -0,0 +1,178 @@
+package docs
+
+import (
+	"reflect"
+	"testing"
+)
+
+// block is the part of a Node the tests compare.
+type block struct {
+	Kind    NodeKind
+	Line    int
+	Level   int
+	Quote   int
+	Callout string
+	Marker  string
+	Text    string
+}
+
+func blocks(nodes []Node) []block {
+	var out []block
+	for _, n := range nodes {
+		text := ""
+		for _, s := range n.Spans {
+			text += s.Text
+		}
+		out = append(out, block{n.Kind, n.Line, n.Level, n.Quote, n.Callout, n.Marker, text})
+	}
+	return out
+}
+
+func TestParseMarkdown(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []block
+	}{
+		{
+			name:    "empty front matter",
+			content: "---\n---\n# Title",
+			want: []block{
+				{Kind: FrontMatter},
+				{Kind: Heading, Line: 2, Level: 1, Text: "Title"},
+			},
+		},
+		{
+			name:    "empty code block",
+			content: "```\n```\ntext",
+			want: []block{
+				{Kind: CodeBlock},
+				{Kind: Paragraph, Line: 2, Text: "text"},
+			},
+		},
+		{
+			name:    "paragraph lines join",
+			content: "one\ntwo\n\nthree",
+			want: []block{
+				{Kind: Paragraph, Text: "one two"},
+				{Kind: Paragraph, Line: 3, Text: "three"},
+			},
+		},
+		{
+			name:    "nested lists",
+			content: "* a\n    * b\n      more b\n* c\n\n  in c\n\nafter",
+			want: []block{
+				{Kind: ListItem, Marker: "*", Text: "a"},
+				{Kind: ListItem, Line: 1, Level: 1, Marker: "*", Text: "b more b"},
+				{Kind: ListItem, Line: 3, Marker: "*", Text: "c"},
+				{Kind: Paragraph, Line: 5, Level: 1, Text: "in c"},
+				{Kind: Paragraph, Line: 7, Text: "after"},
+			},
+		},
+		{
+			name:    "ordered list",
+			content: "1. one\n2) two",
+			want: []block{
+				{Kind: ListItem, Marker: "1.", Text: "one"},
+				{Kind: ListItem, Line: 1, Marker: "2)", Text: "two"},
+			},
+		},
+		{
+			name:    "nested quotes",
+			content: "> outer\n> > inner\n> # heading",
+			want: []block{
+				{Kind: Paragraph, Quote: 1, Text: "outer"},
+				{Kind: Paragraph, Line: 1, Quote: 2, Text: "inner"},
+				{Kind: Heading, Line: 2, Level: 1, Quote: 1, Text: "heading"},
+			},
+		},
+		{
+			name:    "callouts",
+			content: "~> **Note:** careful\ncontinued\n\n-> note\n\n!> danger",
+			want: []block{
+				{Kind: Paragraph, Quote: 1, Callout: "warning", Text: "Note: careful continued"},
+				{Kind: Paragraph, Line: 3, Quote: 1, Callout: "note", Text: "note"},
+				{Kind: Paragraph, Line: 5, Quote: 1, Callout: "danger", Text: "danger"},
+			},
+		},
+		{
+			name:    "rule is not a list",
+			content: "* * *\n---",
+			want: []block{
+				{Kind: Rule},
+				{Kind: Rule, Line: 1},
+			},
+		},
+		{
+			name:    "html comments are skipped",
+			content: "<!-- a\nb -->\ntext",
+			want: []block{
+				{Kind: Paragraph, Line: 2, Text: "text"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := blocks(ParseMarkdown(tt.content))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseMarkdown(%q)\n got %+v\nwant %+v", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMarkdownCode(t *testing.T) {
+	nodes := ParseMarkdown("* item\n\n  ```hcl\n  a = 1\n    b = 2\n  ```")
+	if len(nodes) != 2 {
+		t.Fatalf("got %d nodes, want 2", len(nodes))
+	}
+	code := nodes[1]
+	if code.Kind != CodeBlock || code.Lang != "hcl" || code.Level != 1 {
+		t.Errorf("got %+v, want an hcl code block one list deep", code)
+	}
+	if want := []string{"a = 1", "  b = 2"}; !reflect.DeepEqual(code.Lines, want) {
+		t.Errorf("got lines %q, want %q", code.Lines, want)
+	}
+}
+
+func TestParseMarkdownTable(t *testing.T) {
+	nodes := ParseMarkdown("| Name | Value |\n|------|:-----:|\n| a | `b` |\n\nafter")
+	if len(nodes) != 2 || nodes[0].Kind != Table {
+		t.Fatalf("got %+v, want a table and a paragraph", blocks(nodes))
+	}
+	want := [][][]Span{
+		{{{Text: "Name"}}, {{Text: "Value"}}},
+		{{{Text: "a"}}, {{Text: "b", Style: Code}}},
+	}
+	if !reflect.DeepEqual(nodes[0].Rows, want) {
+		t.Errorf("got rows %+v, want %+v", nodes[0].Rows, want)
+	}
+}
+
+func TestParseInline(t *testing.T) {
+	tests := []struct {
+		text string
+		want []Span
+	}{
+		{"plain", []Span{{Text: "plain"}}},
+		{"**bold** and *em*", []Span{{Text: "bold", Style: Strong}, {Text: " and "}, {Text: "em", Style: Emphasis}}},
+		{"***both***", []Span{{Text: "both", Style: Strong | Emphasis}}},
+		{"~~gone~~", []Span{{Text: "gone", Style: Strike}}},
+		{"`a*b*`", []Span{{Text: "a*b*", Style: Code}}},
+		{"``a ` b``", []Span{{Text: "a ` b", Style: Code}}},
+		{"snake_case_name", []Span{{Text: "snake_case_name"}}},
+		{"2 * 3 * 4", []Span{{Text: "2 * 3 * 4"}}},
+		{`\*not em\*`, []Span{{Text: "*not em*"}}},
+		{"[text](https://x.io \"title\")", []Span{{Text: "text", Style: Link, Link: "https://x.io"}}},
+		{"[**b**](#a)", []Span{{Text: "b", Style: Link | Strong, Link: "#a"}}},
+		{"<https://x.io>", []Span{{Text: "https://x.io", Style: Link, Link: "https://x.io"}}},
+		{"a<!-- c -->b<br>c", []Span{{Text: "ab c"}}},
+		{`<a id="x"></a>text`, []Span{{Text: "text"}}},
+		{"a < b", []Span{{Text: "a < b"}}},
+	}
+	for _, tt := range tests {
+		if got := ParseInline(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseInline(%q)\n got %+v\nwant %+v", tt.text, got, tt.want)
+		}
+	}
+}
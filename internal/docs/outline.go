@@ -0,0 +1,76 @@
+package docs
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var linkPattern = regexp.MustCompile(`\[[^\]]*\]\(([^)\s]+)[^)]*\)|<(https?://[^>\s]+)>`)
+var anchorTag = regexp.MustCompile(`<a (?:id|name)="([^"]+)"`)
+
+// Outline records where a doc's headings and link anchors are, by line
+// number counted from 0. Code blocks are skipped.
+type Outline struct {
+	Headings map[int]string
+	Anchors  map[string]int
+}
+
+func ParseOutline(content string) Outline {
+	o := Outline{Headings: map[int]string{}, Anchors: map[string]int{}}
+	var openFence string
+	for i, l := range strings.Split(content, "\n") {
+		if openFence != "" {
+			if strings.TrimSpace(l) == openFence {
+				openFence = ""
+			}
+			continue
+		}
+		if f, ok := fence(l); ok {
+			openFence = f
+			continue
+		}
+		if text, ok := headingText(l); ok {
+			o.Headings[i] = text
+			o.Anchors[Anchor(text)] = i
+		}
+		for _, m := range anchorTag.FindAllStringSubmatch(l, -1) {
+			o.Anchors[m[1]] = i
+		}
+	}
+	return o
+}
+
+// Anchor turns heading text into the fragment that links to it.
+func Anchor(heading string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(heading) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '-', r == '_':
+			sb.WriteRune(r)
+		case r == ' ':
+			sb.WriteRune('-')
+		}
+	}
+	return sb.String()
+}
+
+// LinkAt returns where the markdown link covering the rune at offset in line
+// points.
+func LinkAt(line string, offset int) (string, bool) {
+	runes := []rune(line)
+	if offset < 0 || offset >= len(runes) {
+		return "", false
+	}
+	at := len(string(runes[:offset]))
+	for _, m := range linkPattern.FindAllStringSubmatchIndex(line, -1) {
+		if at < m[0] || at >= m[1] {
+			continue
+		}
+		if m[2] >= 0 {
+			return line[m[2]:m[3]], true
+		}
+		return line[m[4]:m[5]], true
+	}
+	return "", false
+}
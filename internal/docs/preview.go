@@ -0,0 +1,40 @@
+package docs
+
+import (
+	"strings"
+)
+
+// Preview trims a doc down to what's useful at a glance: its front matter,
+// the introduction under the title and the Example Usage section.
+func Preview(content string) string {
+	lines := strings.Split(content, "\n")
+	var out []string
+
+	i := 0
+	if len(lines) > 0 && strings.TrimSpace(lines[0]) == "---" {
+		end := 1
+		for end < len(lines) && strings.TrimSpace(lines[end]) != "---" {
+			end++
+		}
+		out = append(out, lines[:min(end+1, len(lines))]...)
+		i = end + 1
+	}
+
+	var section, openFence string
+	for ; i < len(lines); i++ {
+		l := lines[i]
+		if openFence != "" {
+			if strings.TrimSpace(l) == openFence {
+				openFence = ""
+			}
+		} else if f, ok := fence(l); ok {
+			openFence = f
+		} else if strings.HasPrefix(l, "## ") {
+			section, _ = headingText(l)
+		}
+		if section == "" || section == "Example Usage" {
+			out = append(out, l)
+		}
+	}
+	return strings.TrimSpace(strings.Join(out, "\n"))
+}
@@ -0,0 +1,361 @@
+package docs
+
+import (
+	"regexp"
+	"strings"
+)
+
+type Kind string
+
+const (
+	KindArgument  Kind = "argument"
+	KindAttribute Kind = "attribute"
+	KindTimeout   Kind = "timeout"
+)
+
+type Field struct {
+	Name        string   `json:"name"`
+	Kind        Kind     `json:"kind"`
+	Path        []string `json:"path,omitempty"`
+	Block       bool     `json:"block,omitempty"`
+	Required    bool     `json:"required"`
+	Optional    bool     `json:"optional"`
+	Computed    bool     `json:"computed"`
+	Deprecated  bool     `json:"deprecated"`
+	Description string   `json:"description"`
+}
+
+func (f Field) FullName() string {
+	return strings.Join(append(append([]string{}, f.Path...), f.Name), ".")
+}
+
+func (f Field) Requirement() string {
+	switch {
+	case f.Required:
+		return "required"
+	case f.Optional && f.Computed:
+		return "optional, computed"
+	case f.Optional:
+		return "optional"
+	case f.Computed:
+		return "computed"
+	}
+	return ""
+}
+
+func (f Field) Summary() string {
+	return summary(f.Description)
+}
+
+type Reference struct {
+	Fields []Field `json:"fields"`
+}
+
+func (r Reference) Kind(k Kind) []Field {
+	var ret []Field
+	for _, f := range r.Fields {
+		if f.Kind == k {
+			ret = append(ret, f)
+		}
+	}
+	return ret
+}
+
+type Argument struct {
+	Name        string
+	Required    bool
+	Description string
+}
+
+type Block struct {
+	Name        string
+	Required    bool
+	Description string
+	Arguments   []Argument
+	Blocks      []*Block
+}
+
+var entryRegex = regexp.MustCompile("^\\s*[*-]\\s+`([A-Za-z0-9_.]+)`\\s*(?:-\\s*)?(?:\\(([^)]*)\\))?\\s*[-:]?\\s*(.*)$")
+var blockParagraphRegex = regexp.MustCompile("(?i)^(?:<a [^>]*>\\s*</a>\\s*)?(?:the\\s+|each\\s+)?`([A-Za-z0-9_.]+)`\\s+(?:configuration\\s+)?(?:block\\s+)?(?:supports|contains|has|accepts|exports)")
+var blockHeadingRegex = regexp.MustCompile("(?i)^(?:nested schema for\\s+)?`?([A-Za-z0-9_.]+)`?(?:\\s+(?:configuration\\s+)?blocks?)?(?:\\s+(?:arguments|attributes))?$")
+var requirednessRegex = regexp.MustCompile("(?i)following arguments are (required|optional)|^(required|optional|read-only):$")
+
+func isReferenceHeading(text string, names ...string) bool {
+	lower := strings.ToLower(strings.Trim(text, "` "))
+	for _, n := range names {
+		if lower == n {
+			return true
+		}
+	}
+	return false
+}
+
+func sectionKind(text string) (Kind, bool) {
+	switch {
+	case isReferenceHeading(text, "argument reference", "arguments reference", "schema"):
+		return KindArgument, true
+	case isReferenceHeading(text, "attribute reference", "attributes reference"):
+		return KindAttribute, true
+	case isReferenceHeading(text, "timeouts"):
+		return KindTimeout, true
+	}
+	return "", false
+}
+
+func headingLevel(line string) int {
+	return len(line) - len(strings.TrimLeft(line, "#"))
+}
+
+func summary(desc string) string {
+	if i := strings.Index(desc, ". "); i >= 0 {
+		return desc[:i+1]
+	}
+	return desc
+}
+
+func (a Argument) Summary() string {
+	return summary(a.Description)
+}
+
+func (b Block) Summary() string {
+	return summary(b.Description)
+}
+
+func parseEntry(line string, kind Kind, defaultRequired bool) (Field, bool) {
+	m := entryRegex.FindStringSubmatch(line)
+	if m == nil {
+		return Field{}, false
+	}
+	f := Field{
+		Name:        m[1],
+		Kind:        kind,
+		Required:    defaultRequired,
+		Optional:    kind == KindArgument && !defaultRequired,
+		Computed:    kind == KindAttribute,
+		Description: strings.TrimSpace(m[3]),
+	}
+	for _, flag := range strings.Split(strings.ToLower(m[2]), ",") {
+		switch strings.Trim(flag, " *") {
+		case "required":
+			f.Required, f.Optional = true, false
+		case "optional":
+			f.Required, f.Optional = false, true
+		case "computed":
+			f.Computed = true
+		case "deprecated":
+			f.Deprecated = true
+		}
+	}
+	if kind == KindTimeout && m[2] != "" {
+		f.Description = strings.TrimSpace(m[2] + " " + f.Description)
+	}
+	lower := strings.ToLower(f.Description)
+	if strings.HasPrefix(lower, "deprecated") || strings.Contains(lower, "**deprecated**") {
+		f.Deprecated = true
+	}
+	return f, true
+}
+
+type node struct {
+	kind   Kind
+	path   []string
+	fields []int
+}
+
+type referenceParser struct {
+	ref   Reference
+	nodes []*node
+}
+
+func (p *referenceParser) root(kind Kind) *node {
+	for _, n := range p.nodes {
+		if n.kind == kind && len(n.path) == 0 {
+			return n
+		}
+	}
+	n := &node{kind: kind}
+	p.nodes = append(p.nodes, n)
+	return n
+}
+
+func (p *referenceParser) parentOf(kind Kind, name string) *node {
+	for i := len(p.nodes) - 1; i >= 0; i-- {
+		n := p.nodes[i]
+		if n.kind != kind {
+			continue
+		}
+		for _, fi := range n.fields {
+			if p.ref.Fields[fi].Name == name {
+				p.ref.Fields[fi].Block = true
+				return n
+			}
+		}
+	}
+	return p.root(kind)
+}
+
+// listed reports whether a field called name has been seen in kind's
+// section.
+func (p *referenceParser) listed(kind Kind, name string) bool {
+	for _, f := range p.ref.Fields {
+		if f.Kind == kind && f.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func lastName(path string) string {
+	return path[strings.LastIndex(path, ".")+1:]
+}
+
+func (p *referenceParser) defineBlock(kind Kind, path string) *node {
+	name := lastName(path)
+	parent := p.parentOf(kind, name)
+	n := &node{
+		kind: kind,
+		path: append(append([]string{}, parent.path...), name),
+	}
+	p.nodes = append(p.nodes, n)
+	return n
+}
+
+func (p *referenceParser) add(n *node, f Field) {
+	f.Path = n.path
+	n.fields = append(n.fields, len(p.ref.Fields))
+	p.ref.Fields = append(p.ref.Fields, f)
+}
+
+func ParseReference(content string) Reference {
+	p := referenceParser{}
+	var current *node
+	var kind Kind
+	sectionLevel := 0
+	defaultRequired, readOnly := false, false
+	openFence := ""
+
+	for _, l := range strings.Split(content, "\n") {
+		if openFence != "" {
+			if strings.TrimSpace(l) == openFence {
+				openFence = ""
+			}
+			continue
+		}
+		if f, ok := fence(l); ok {
+			openFence = f
+			continue
+		}
+
+		if text, ok := headingText(l); ok {
+			level := headingLevel(l)
+			if k, ok := sectionKind(text); ok && (sectionLevel == 0 || level <= sectionLevel || k == KindTimeout) {
+				kind, sectionLevel = k, level
+				current = p.root(kind)
+				defaultRequired, readOnly = false, false
+				continue
+			}
+			switch {
+			case sectionLevel == 0:
+			case level <= sectionLevel:
+				sectionLevel = 0
+			case isReferenceHeading(text, "required"):
+				defaultRequired, readOnly = true, false
+			case isReferenceHeading(text, "optional"):
+				defaultRequired, readOnly = false, false
+			case isReferenceHeading(text, "read-only"):
+				readOnly = true
+			default:
+				// Only headings naming a field start its block. Others, like
+				// "Rules" or "Statement", just leave the block before them.
+				if m := blockHeadingRegex.FindStringSubmatch(text); m != nil && p.listed(kind, lastName(m[1])) {
+					current = p.defineBlock(kind, m[1])
+				} else {
+					current = p.root(kind)
+				}
+				defaultRequired, readOnly = false, false
+			}
+			continue
+		}
+		if sectionLevel == 0 {
+			continue
+		}
+
+		// Generated docs start the lists of a nested schema with "Required:",
+		// "Optional:" or "Read-Only:" rather than a heading.
+		if m := requirednessRegex.FindStringSubmatch(strings.TrimSpace(l)); m != nil {
+			which := strings.ToLower(m[1] + m[2])
+			defaultRequired, readOnly = which == "required", which == "read-only"
+			continue
+		}
+		if m := blockParagraphRegex.FindStringSubmatch(l); m != nil {
+			current = p.defineBlock(kind, m[1])
+			defaultRequired = false
+			continue
+		}
+		if f, ok := parseEntry(l, kind, defaultRequired); ok {
+			if readOnly {
+				f.Kind, f.Required, f.Optional, f.Computed = KindAttribute, false, false, true
+			}
+			p.add(current, f)
+		}
+	}
+	return p.ref
+}
+
+func blockAt(root *Block, path []string) *Block {
+	b := root
+	for _, name := range path {
+		var next *Block
+		for _, child := range b.Blocks {
+			if child.Name == name {
+				next = child
+			}
+		}
+		if next == nil {
+			next = &Block{Name: name}
+			b.Blocks = append(b.Blocks, next)
+		}
+		b = next
+	}
+	return b
+}
+
+func (r Reference) Arguments() *Block {
+	root := &Block{}
+	for _, f := range r.Kind(KindArgument) {
+		if f.Block {
+			b := blockAt(root, append(append([]string{}, f.Path...), f.Name))
+			b.Required = f.Required
+			b.Description = f.Description
+			continue
+		}
+		b := blockAt(root, f.Path)
+		b.Arguments = append(b.Arguments, Argument{
+			Name:        f.Name,
+			Required:    f.Required,
+			Description: f.Description,
+		})
+	}
+	return root
+}
+
+func ArgumentReference(content string) *Block {
+	return ParseReference(content).Arguments()
+}
+
+func (r Reference) Table() ([]string, [][]string) {
+	headers := []string{"NAME", "KIND", "REQUIREMENT", "DEPRECATED", "DESCRIPTION"}
+	rows := make([][]string, len(r.Fields))
+	for i, f := range r.Fields {
+		deprecated := ""
+		if f.Deprecated {
+			deprecated = "yes"
+		}
+		name := f.FullName()
+		if f.Block {
+			name += " {}"
+		}
+		rows[i] = []string{name, string(f.Kind), f.Requirement(), deprecated, f.Description}
+	}
+	return headers, rows
+}
@@ -0,0 +1,306 @@
+package docs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// describe sums up a field as "path.name[ {}] kind requirement[ deprecated]".
+func describe(f Field) string {
+	name := f.FullName()
+	if f.Block {
+		name += " {}"
+	}
+	s := strings.TrimSpace(name + " " + string(f.Kind) + " " + f.Requirement())
+	if f.Deprecated {
+		s += " deprecated"
+	}
+	return s
+}
+
+const classicDoc = `---
+subcategory: "ELB (Elastic Load Balancing)"
+---
+
+# Resource: aws_lb_listener
+
+## Example Usage
+
+` + "```terraform" + `
+resource "aws_lb_listener" "front_end" {
+  * ` + "`not_an_argument`" + ` - (Required) Inside a code block.
+}
+` + "```" + `
+
+## Argument Reference
+
+The following arguments are required:
+
+* ` + "`load_balancer_arn`" + ` - (Required, Forces new resource) ARN of the load balancer.
+* ` + "`default_action`" + ` - (Required) Configuration block for default actions. See below.
+
+The following arguments are optional:
+
+* ` + "`port`" + ` - (Optional) Port on which the load balancer is listening.
+* ` + "`ssl_policy`" + ` - (Optional, **Deprecated**) Name of the SSL Policy.
+* ` + "`alpn_policy`" + ` - (Optional) Deprecated. Use ` + "`alpn`" + ` instead.
+* ` + "`certificate_arn`" + ` - (Optional) ARN of the certificate. **Deprecated** in favour of ` + "`certificates`" + `.
+
+### default_action
+
+The following arguments are required:
+
+* ` + "`type`" + ` - (Required) Type of routing action.
+
+The following arguments are optional:
+
+* ` + "`forward`" + ` - (Optional) Configuration block for forwarding. See below.
+
+#### forward
+
+* ` + "`target_group`" + ` - (Required) Set of target groups.
+
+## Attribute Reference
+
+This resource exports the following attributes in addition to the arguments above:
+
+* ` + "`arn`" + ` - ARN of the listener.
+* ` + "`tags_all`" + ` - Map of tags assigned to the resource.
+
+## Timeouts
+
+[Configuration options](https://developer.hashicorp.com/terraform/language/resources/syntax#operation-timeouts):
+
+* ` + "`create`" + ` - (Default ` + "`5m`" + `)
+* ` + "`update`" + ` - (Default ` + "`5m`" + `)
+
+## Import
+
+* ` + "`listener_id`" + ` - not a reference entry.
+`
+
+const schemaDoc = `# google_cloud_run_v2_job (Resource)
+
+<!-- schema generated by tfplugindocs -->
+## Schema
+
+### Required
+
+- ` + "`name`" + ` (String) Name of the Job.
+- ` + "`template`" + ` (Block List, Min: 1, Max: 1) The template used to create executions. (see [below for nested schema](#nestedblock--template))
+
+### Optional
+
+- ` + "`labels`" + ` (Map of String) Labels.
+- ` + "`launch_stage`" + ` (String, Deprecated) The launch stage.
+
+### Read-Only
+
+- ` + "`id`" + ` (String) The ID of this resource.
+
+<a id="nestedblock--template"></a>
+### Nested Schema for ` + "`template`" + `
+
+Required:
+
+- ` + "`container`" + ` (Block List, Min: 1) Containers. (see [below for nested schema](#nestedblock--template--container))
+
+Optional:
+
+- ` + "`task_count`" + ` (Number) Number of tasks.
+
+Read-Only:
+
+- ` + "`revision`" + ` (String) The revision.
+
+<a id="nestedblock--template--container"></a>
+### Nested Schema for ` + "`template.container`" + `
+
+Required:
+
+- ` + "`image`" + ` (String) The image.
+
+Optional:
+
+- ` + "`args`" + ` (List of String) Arguments.
+`
+
+const paragraphBlocksDoc = `## Argument Reference
+
+* ` + "`name`" + ` - (Required) Name of the listener rule.
+* ` + "`default_action`" + ` - (Required) An action block. Action blocks are documented below.
+
+The ` + "`default_action`" + ` block supports:
+
+* ` + "`type`" + ` - (Required) The type of routing action.
+* ` + "`redirect`" + ` - (Optional) Information for creating a redirect action.
+
+` + "`redirect`" + ` configuration block supports the following:
+
+* ` + "`host`" + ` - (Optional) The hostname.
+* ` + "`status_code`" + ` - (Required) The HTTP redirect code.
+
+## Attributes Reference
+
+In addition to all arguments above, the following attributes are exported:
+
+* ` + "`id`" + ` - The ARN of the rule.
+
+The ` + "`redirect`" + ` block exports:
+
+* ` + "`path`" + ` - The path redirected to.
+`
+
+const proseHeadingsDoc = `## Argument Reference
+
+This resource supports the following arguments:
+
+* ` + "`name`" + ` - (Required, Forces new resource) A friendly name of the rule group.
+* ` + "`rule`" + ` - (Optional) The rule blocks used to identify the web requests. See Rules below for details.
+
+### Rules
+
+Each ` + "`rule`" + ` supports the following arguments:
+
+* ` + "`priority`" + ` - (Required) The order in which rules are evaluated.
+* ` + "`statement`" + ` - (Required) The AWS WAF processing statement for the rule. See Statement below for details.
+
+### Statement
+
+The ` + "`statement`" + ` block supports the following arguments:
+
+* ` + "`and_statement`" + ` - (Optional) A logical rule statement used to combine other rule statements with AND logic.
+
+### AND Statement
+
+The ` + "`and_statement`" + ` block supports the following arguments:
+
+* ` + "`statement`" + ` - (Required) The statements to combine with AND logic.
+`
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name:    "required and optional paragraphs, nested headings, attributes and timeouts",
+			content: classicDoc,
+			want: []string{
+				"load_balancer_arn argument required",
+				"default_action {} argument required",
+				"port argument optional",
+				"ssl_policy argument optional deprecated",
+				"alpn_policy argument optional deprecated",
+				"certificate_arn argument optional deprecated",
+				"default_action.type argument required",
+				"default_action.forward {} argument optional",
+				"default_action.forward.target_group argument required",
+				"arn attribute computed",
+				"tags_all attribute computed",
+				"create timeout",
+				"update timeout",
+			},
+		},
+		{
+			name:    "generated schema with nested schema headings",
+			content: schemaDoc,
+			want: []string{
+				"name argument required",
+				"template {} argument required",
+				"labels argument optional",
+				"launch_stage argument optional deprecated",
+				"id attribute computed",
+				"template.container {} argument required",
+				"template.task_count argument optional",
+				"template.revision attribute computed",
+				"template.container.image argument required",
+				"template.container.args argument optional",
+			},
+		},
+		{
+			name:    "blocks introduced by a paragraph",
+			content: paragraphBlocksDoc,
+			want: []string{
+				"name argument required",
+				"default_action {} argument required",
+				"default_action.type argument required",
+				"default_action.redirect {} argument optional",
+				"default_action.redirect.host argument optional",
+				"default_action.redirect.status_code argument required",
+				"id attribute computed",
+				"redirect.path attribute computed",
+			},
+		},
+		{
+			name:    "headings that don't name a block",
+			content: proseHeadingsDoc,
+			want: []string{
+				"name argument required",
+				"rule {} argument optional",
+				"rule.priority argument required",
+				"rule.statement {} argument required",
+				"rule.statement.and_statement {} argument optional",
+				"rule.statement.and_statement.statement argument required",
+			},
+		},
+		{
+			name:    "no reference sections",
+			content: "# aws_thing\n\n* `name` - (Required) Not under a reference heading.\n",
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, f := range ParseReference(tt.content).Fields {
+				got = append(got, describe(f))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestParseReferenceDescriptions(t *testing.T) {
+	ref := ParseReference(classicDoc)
+	want := map[string]string{
+		"load_balancer_arn": "ARN of the load balancer.",
+		"default_action":    "Configuration block for default actions. See below.",
+		"create":            "Default `5m`",
+	}
+	for _, f := range ref.Fields {
+		if d, ok := want[f.FullName()]; ok && f.Description != d {
+			t.Errorf("%s: got description %q, want %q", f.FullName(), f.Description, d)
+		}
+	}
+	if got := ref.Fields[1].Summary(); got != "Configuration block for default actions." {
+		t.Errorf("got summary %q", got)
+	}
+}
+
+func TestReferenceArguments(t *testing.T) {
+	root := ParseReference(schemaDoc).Arguments()
+	var names []string
+	for _, a := range root.Arguments {
+		names = append(names, a.Name)
+	}
+	if want := []string{"name", "labels", "launch_stage"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("got top-level arguments %q, want %q", names, want)
+	}
+	if len(root.Blocks) != 1 || root.Blocks[0].Name != "template" || !root.Blocks[0].Required {
+		t.Fatalf("got blocks %+v, want a required template block", root.Blocks)
+	}
+	template := root.Blocks[0]
+	if len(template.Blocks) != 1 || template.Blocks[0].Name != "container" || len(template.Blocks[0].Arguments) != 2 {
+		t.Errorf("got template blocks %+v, want container with image and args", template.Blocks)
+	}
+	for _, a := range template.Arguments {
+		if a.Name == "revision" {
+			t.Error("read-only revision listed as an argument")
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
 )
 
 func DiscoverLockFile() (string, error) {
@@ -31,7 +32,7 @@ func GetProvidersFromLockFile(filePath string) []TerraformProvider {
 	}
 	defer file.Close()
 	scanner := bufio.NewScanner(file)
-	r, err := regexp.Compile("registry.terraform.io/(.*)\"\\s")
+	r, err := regexp.Compile("(registry\\.terraform\\.io)/(.*)\"\\s")
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -41,13 +42,15 @@ func GetProvidersFromLockFile(filePath string) []TerraformProvider {
 		log.Fatal(err)
 	}
 
-	provider := ""
+	host, provider := "", ""
 
 	for scanner.Scan() {
 		if provider == "" && r.MatchString(scanner.Text()) {
-			provider = r.FindStringSubmatch(scanner.Text())[1]
+			m := r.FindStringSubmatch(scanner.Text())
+			host, provider = m[1], m[2]
 		} else if provider != "" && r2.MatchString(scanner.Text()) {
 			providers = append(providers, TerraformProvider{
+				Host:    host,
 				Name:    provider,
 				Version: r2.FindStringSubmatch(scanner.Text())[1],
 			})
@@ -58,3 +61,28 @@ func GetProvidersFromLockFile(filePath string) []TerraformProvider {
 
 	return providers
 }
+
+var blockPattern = regexp.MustCompile(`^\s*(resource|data|ephemeral)\s+"([^"]+)"`)
+
+// ProjectBlocks finds the resources, data sources and ephemeral resources
+// declared in the .tf files in dir, keyed like "data aws_ami". A file that
+// can't be read is skipped, so one bad file doesn't hide the others' blocks.
+func ProjectBlocks(dir string) (map[string]bool, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if err != nil {
+		return nil, err
+	}
+	blocks := map[string]bool{}
+	for _, path := range files {
+		body, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		for _, l := range strings.Split(string(body), "\n") {
+			if m := blockPattern.FindStringSubmatch(l); m != nil {
+				blocks[m[1]+" "+m[2]] = true
+			}
+		}
+	}
+	return blocks, nil
+}
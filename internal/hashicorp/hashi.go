@@ -2,8 +2,8 @@ package hashicorp
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"net/url"
 )
@@ -23,48 +23,129 @@ func (c *Client) SetBaseUrl(baseUrl string) *Client {
 	return c
 }
 
-func (c Client) callRegistryUrl(path string) []byte {
+func (c Client) get(path string) (*http.Response, error) {
 	url, err := url.Parse(c.baseUrl + path)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 	res, err := http.Get(url.String())
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		return nil, fmt.Errorf("registry returned %s for %s", res.Status, path)
+	}
+	return res, nil
+}
+
+func (c Client) callRegistryUrl(path string, result any) error {
+	res, err := c.get(path)
+	if err != nil {
+		return err
 	}
 	defer res.Body.Close()
-	log.Print(res.Status)
 	body, err := io.ReadAll(res.Body)
 	if err != nil {
-		log.Fatal(err)
+		return err
+	}
+	return json.Unmarshal(body, result)
+}
+
+// streamIncluded calls each with the items of the response's included list
+// as they're decoded, rather than once the whole body has arrived.
+func streamIncluded[T any](c Client, path string, each func(T)) error {
+	res, err := c.get(path)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	dec := json.NewDecoder(res.Body)
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if key != "included" {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+			continue
+		}
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if tok == nil {
+			continue
+		}
+		if tok != json.Delim('[') {
+			return fmt.Errorf("unexpected %v in registry response, expected [", tok)
+		}
+		for dec.More() {
+			var t T
+			if err := dec.Decode(&t); err != nil {
+				return err
+			}
+			each(t)
+		}
+		if err := expectDelim(dec, ']'); err != nil {
+			return err
+		}
 	}
-	return body
+	return nil
 }
 
-func (c *Client) GetProviderId(provider string) string {
-	body := c.callRegistryUrl("providers/" + provider)
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != want {
+		return fmt.Errorf("unexpected %v in registry response, expected %v", tok, want)
+	}
+	return nil
+}
+
+func (c *Client) GetProviderId(provider string) (string, error) {
 	var result ProviderRes
-	json.Unmarshal(body, &result)
-	return string(result.Data.Id)
+	if err := c.callRegistryUrl("providers/"+provider, &result); err != nil {
+		return "", err
+	}
+	return string(result.Data.Id), nil
 }
 
-func (c *Client) GetProviderVersions(provider string) ProviderRes {
-	body := c.callRegistryUrl("providers/" + provider + "?include=provider-versions")
+func (c *Client) GetProviderVersions(provider string) (ProviderRes, error) {
 	var result ProviderRes
-	json.Unmarshal(body, &result)
-	return result
+	err := c.callRegistryUrl("providers/"+provider+"?include=provider-versions", &result)
+	return result, err
 }
 
-func (c *Client) GetProviderVersionResources(version string) ProviderVersionRes {
-	body := c.callRegistryUrl("provider-versions/" + version + "?include=provider-docs")
+func (c *Client) GetProviderVersionResources(version string) (ProviderVersionRes, error) {
 	var result ProviderVersionRes
-	json.Unmarshal(body, &result)
-	return result
+	err := c.callRegistryUrl("provider-versions/"+version+"?include=provider-docs", &result)
+	return result, err
 }
 
-func (c *Client) GetResourceDoc(resource string) string {
-	body := c.callRegistryUrl("provider-docs/" + resource)
+// StreamProviderVersions calls each with provider's versions as they're read.
+func (c *Client) StreamProviderVersions(provider string, each func(Version)) error {
+	return streamIncluded(*c, "providers/"+provider+"?include=provider-versions", each)
+}
+
+// StreamProviderVersionResources calls each with the docs of version as
+// they're read.
+func (c *Client) StreamProviderVersionResources(version string, each func(Resource)) error {
+	return streamIncluded(*c, "provider-versions/"+version+"?include=provider-docs", each)
+}
+
+func (c *Client) GetResourceDoc(resource string) (string, error) {
 	var result ProviderDocRes
-	json.Unmarshal(body, &result)
-	return result.Data.Attributes.Content
+	if err := c.callRegistryUrl("provider-docs/"+resource, &result); err != nil {
+		return "", err
+	}
+	return result.Data.Attributes.Content, nil
 }
@@ -0,0 +1,72 @@
+package hashicorp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestStreamProviderVersionResources(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:   "included after data",
+			status: http.StatusOK,
+			body:   `{"data":{"id":"1","attributes":{"version":"5.0.0"}},"included":[{"id":"a","attributes":{"slug":"vpc"}},{"id":"b","attributes":{"slug":"subnet"}}],"links":{}}`,
+			want:   []string{"vpc", "subnet"},
+		},
+		{
+			name:   "included first",
+			status: http.StatusOK,
+			body:   `{"included":[{"id":"a","attributes":{"slug":"vpc"}}],"data":{"id":"1"}}`,
+			want:   []string{"vpc"},
+		},
+		{
+			name:   "no included",
+			status: http.StatusOK,
+			body:   `{"data":{"id":"1"},"included":null}`,
+		},
+		{
+			name:    "error status",
+			status:  http.StatusServiceUnavailable,
+			body:    `{}`,
+			wantErr: true,
+		},
+		{
+			name:    "truncated",
+			status:  http.StatusOK,
+			body:    `{"included":[{"id":"a","attributes":{"slug":"vpc"}},{"id":`,
+			want:    []string{"vpc"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/provider-versions/1" || r.URL.Query().Get("include") != "provider-docs" {
+					t.Errorf("unexpected request %s", r.URL)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			var got []string
+			err := NewClient().SetBaseUrl(srv.URL+"/").StreamProviderVersionResources("1", func(r Resource) {
+				got = append(got, r.Attributes.Slug)
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 )
 
 type TerraformProvider struct {
+	Host    string
 	Name    string
 	Version string
 }
@@ -41,8 +42,11 @@ type Resource struct {
 	Type       string
 	Id         string
 	Attributes struct {
-		Category string
-		Slug     string
+		Category    string
+		Slug        string
+		Subcategory string
+		Title       string
+		Path        string
 	}
 	Links map[string]interface{}
 }
@@ -0,0 +1,42 @@
+package hclgen
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/StateOfDenial/tfpd/internal/docs"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// docReference parses testdata/name.md, a provider doc.
+func docReference(t *testing.T, name string) docs.Reference {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join("testdata", name+".md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return docs.ParseReference(string(content))
+}
+
+// checkGolden compares got with testdata/file, or rewrites the file when run
+// with -update.
+func checkGolden(t *testing.T, file, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", file)
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != string(want) {
+		t.Errorf("%s differs, got:\n%s", path, got)
+	}
+}
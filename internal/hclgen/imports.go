@@ -0,0 +1,14 @@
+package hclgen
+
+import (
+	"fmt"
+)
+
+func ImportBlock(resourceType, name, id string) string {
+	w := writer{}
+	w.line("", "", "import {")
+	w.line("", indentUnit, fmt.Sprintf("to = %s.%s", resourceType, name))
+	w.line("", indentUnit, "id = "+quote(id))
+	w.line("", "", "}")
+	return w.sb.String()
+}
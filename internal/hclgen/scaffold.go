@@ -0,0 +1,85 @@
+package hclgen
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/StateOfDenial/tfpd/internal/docs"
+)
+
+const indentUnit = "  "
+
+func quote(s string) string {
+	quoted := strconv.Quote(s)
+	quoted = strings.ReplaceAll(quoted, "${", "$${")
+	return strings.ReplaceAll(quoted, "%{", "%%{")
+}
+
+type writer struct {
+	sb strings.Builder
+}
+
+func (w *writer) line(prefix, indent, text string) {
+	w.sb.WriteString(strings.TrimRight(prefix+indent+text, " ") + "\n")
+}
+
+func withComment(text, comment string) string {
+	if comment == "" {
+		return text
+	}
+	return text + " # " + comment
+}
+
+func nameWidth(args []docs.Argument) int {
+	width := 0
+	for _, a := range args {
+		width = max(width, len(a.Name))
+	}
+	return width
+}
+
+func (w *writer) body(b *docs.Block, prefix, indent string) {
+	args := uniqueArguments(b.Arguments)
+	width := nameWidth(args)
+	for _, a := range args {
+		if a.Required {
+			assign := fmt.Sprintf("%-*s = %s", width, a.Name, quote("<"+a.Name+">"))
+			w.line(prefix, indent, withComment(assign, a.Summary()))
+		}
+	}
+	for _, a := range args {
+		if !a.Required {
+			assign := fmt.Sprintf("%-*s = null", width, a.Name)
+			w.line(prefix, indent+"# ", withComment(assign, a.Summary()))
+		}
+	}
+
+	spaced := len(args) > 0
+	for _, required := range []bool{true, false} {
+		for _, blk := range b.Blocks {
+			if blk.Required != required {
+				continue
+			}
+			blockPrefix, blockIndent := prefix, indent
+			if !required && !strings.Contains(prefix, "#") {
+				blockPrefix, blockIndent = prefix+indent+"# ", ""
+			}
+			if spaced {
+				w.sb.WriteString("\n")
+			}
+			spaced = true
+			w.line(blockPrefix, blockIndent, withComment(blk.Name+" {", blk.Summary()))
+			w.body(blk, blockPrefix, blockIndent+indentUnit)
+			w.line(blockPrefix, blockIndent, "}")
+		}
+	}
+}
+
+func Scaffold(keyword, resourceType, name string, b *docs.Block) string {
+	w := writer{}
+	w.line("", "", fmt.Sprintf("%s %q %q {", keyword, resourceType, name))
+	w.body(b, "", indentUnit)
+	w.line("", "", "}")
+	return w.sb.String()
+}
@@ -0,0 +1,18 @@
+package hclgen
+
+import "testing"
+
+func TestScaffold(t *testing.T) {
+	tests := []struct {
+		doc, keyword, resourceType, name string
+	}{
+		{"lb_listener", "resource", "aws_lb_listener", "this"},
+		{"wafv2_rule_group", "resource", "aws_wafv2_rule_group", "example"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.doc, func(t *testing.T) {
+			args := docReference(t, tt.doc).Arguments()
+			checkGolden(t, tt.doc+".scaffold.tf", Scaffold(tt.keyword, tt.resourceType, tt.name, args))
+		})
+	}
+}
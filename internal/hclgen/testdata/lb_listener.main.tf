@@ -0,0 +1,50 @@
+resource "aws_lb_listener" "this" {
+  load_balancer_arn = var.load_balancer_arn
+  certificate_arn   = var.certificate_arn
+  port              = var.port
+  protocol          = var.protocol
+  tags              = var.tags
+
+  dynamic "default_action" {
+    for_each = var.default_action == null ? [] : flatten([var.default_action])
+    content {
+      type             = default_action.value.type
+      order            = try(default_action.value.order, null)
+      target_group_arn = try(default_action.value.target_group_arn, null)
+
+      dynamic "forward" {
+        for_each = try(default_action.value.forward, null) == null ? [] : flatten([try(default_action.value.forward, null)])
+        iterator = default_action_forward
+        content {
+          dynamic "target_group" {
+            for_each = try(default_action_forward.value.target_group, null) == null ? [] : flatten([try(default_action_forward.value.target_group, null)])
+            iterator = default_action_forward_target_group
+            content {
+              arn    = default_action_forward_target_group.value.arn
+              weight = try(default_action_forward_target_group.value.weight, null)
+            }
+          }
+
+          dynamic "stickiness" {
+            for_each = try(default_action_forward.value.stickiness, null) == null ? [] : flatten([try(default_action_forward.value.stickiness, null)])
+            iterator = default_action_forward_stickiness
+            content {
+              duration = default_action_forward_stickiness.value.duration
+              enabled  = try(default_action_forward_stickiness.value.enabled, null)
+            }
+          }
+        }
+      }
+
+      dynamic "redirect" {
+        for_each = try(default_action.value.redirect, null) == null ? [] : flatten([try(default_action.value.redirect, null)])
+        iterator = default_action_redirect
+        content {
+          status_code = default_action_redirect.value.status_code
+          host        = try(default_action_redirect.value.host, null)
+          path        = try(default_action_redirect.value.path, null)
+        }
+      }
+    }
+  }
+}
@@ -0,0 +1,31 @@
+resource "aws_lb_listener" "this" {
+  load_balancer_arn = "<load_balancer_arn>" # ARN of the load balancer.
+  # certificate_arn   = null # ARN of the default SSL server certificate.
+  # port              = null # Port on which the load balancer is listening.
+  # protocol          = null # Protocol for connections from clients to the load balancer.
+  # tags              = null # A map of tags to assign to the resource.
+
+  default_action { # Configuration block for default actions.
+    type             = "<type>" # Type of routing action.
+    # order            = null # Order for the action.
+    # target_group_arn = null # ARN of the Target Group to which to route traffic.
+
+    # forward { # Configuration block for creating an action that distributes requests among one or more target groups.
+    #   target_group { # Set of 1-5 target group blocks.
+    #     arn    = "<arn>" # ARN of the target group.
+    #     # weight = null # Weight.
+    #   }
+
+    #   stickiness { # Configuration block for target group stickiness for the rule.
+    #     duration = "<duration>" # Time period, in seconds, during which requests from a client should be routed to the same target group.
+    #     # enabled  = null # Whether target group stickiness is enabled.
+    #   }
+    # }
+
+    # redirect { # Configuration block for creating a redirect action.
+    #   status_code = "<status_code>" # HTTP redirect code.
+    #   # host        = null # Hostname.
+    #   # path        = null # Absolute path, starting with the leading "/".
+    # }
+  }
+}
@@ -0,0 +1,33 @@
+variable "load_balancer_arn" {
+  description = "ARN of the load balancer."
+  type        = any
+}
+
+variable "certificate_arn" {
+  description = "ARN of the default SSL server certificate. Exactly one certificate is required if the protocol is HTTPS."
+  type        = any
+  default     = null
+}
+
+variable "port" {
+  description = "Port on which the load balancer is listening. Not valid for Gateway Load Balancers."
+  type        = any
+  default     = null
+}
+
+variable "protocol" {
+  description = "Protocol for connections from clients to the load balancer. Valid values are `HTTP` and `HTTPS`."
+  type        = any
+  default     = null
+}
+
+variable "tags" {
+  description = "A map of tags to assign to the resource."
+  type        = any
+  default     = null
+}
+
+variable "default_action" {
+  description = "Configuration block for default actions. See below."
+  type        = any
+}
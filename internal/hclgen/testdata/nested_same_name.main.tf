@@ -0,0 +1,16 @@
+resource "example_thing" "this" {
+  dynamic "rule" {
+    for_each = var.rule == null ? [] : flatten([var.rule])
+    content {
+      name = rule.value.name
+
+      dynamic "rule" {
+        for_each = try(rule.value.rule, null) == null ? [] : flatten([try(rule.value.rule, null)])
+        iterator = rule_rule
+        content {
+          name = try(rule_rule.value.name, null)
+        }
+      }
+    }
+  }
+}
@@ -0,0 +1,28 @@
+resource "aws_wafv2_rule_group" "this" {
+  capacity    = var.capacity
+  name        = var.name
+  scope       = var.scope
+  description = var.description
+
+  dynamic "rule" {
+    for_each = var.rule == null ? [] : flatten([var.rule])
+    content {
+      name     = rule.value.name
+      priority = rule.value.priority
+
+      dynamic "statement" {
+        for_each = try(rule.value.statement, null) == null ? [] : flatten([try(rule.value.statement, null)])
+        iterator = rule_statement
+        content {
+          dynamic "and_statement" {
+            for_each = try(rule_statement.value.and_statement, null) == null ? [] : flatten([try(rule_statement.value.and_statement, null)])
+            iterator = rule_statement_and_statement
+            content {
+              statement = rule_statement_and_statement.value.statement
+            }
+          }
+        }
+      }
+    }
+  }
+}
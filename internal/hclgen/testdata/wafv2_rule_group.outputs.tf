@@ -0,0 +1,9 @@
+output "arn" {
+  description = "The ARN of the WAF rule group."
+  value       = aws_wafv2_rule_group.this.arn
+}
+
+output "id" {
+  description = "The ID of the WAF rule group."
+  value       = aws_wafv2_rule_group.this.id
+}
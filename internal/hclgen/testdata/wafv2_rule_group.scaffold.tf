@@ -0,0 +1,17 @@
+resource "aws_wafv2_rule_group" "example" {
+  capacity    = "<capacity>" # The web ACL capacity units (WCUs) required for this rule group.
+  name        = "<name>" # A friendly name of the rule group.
+  scope       = "<scope>" # Specifies whether this is for an AWS CloudFront distribution or for a regional application.
+  # description = null # A friendly description of the rule group.
+
+  # rule { # The rule blocks used to identify the web requests that you want to `allow`, `block`, or `count`.
+  #   name     = "<name>" # A friendly name of the rule.
+  #   priority = "<priority>" # If you define more than one Rule in a WebACL, AWS WAF evaluates each request against the `rules` in order based on the value of `priority`.
+
+  #   statement { # The AWS WAF processing statement for the rule.
+  #     and_statement { # A logical rule statement used to combine other rule statements with AND logic.
+  #       statement = "<statement>" # The statements to combine with AND logic.
+  #     }
+  #   }
+  # }
+}
@@ -0,0 +1,26 @@
+variable "capacity" {
+  description = "The web ACL capacity units (WCUs) required for this rule group."
+  type        = any
+}
+
+variable "name" {
+  description = "A friendly name of the rule group."
+  type        = any
+}
+
+variable "scope" {
+  description = "Specifies whether this is for an AWS CloudFront distribution or for a regional application."
+  type        = any
+}
+
+variable "description" {
+  description = "A friendly description of the rule group."
+  type        = any
+  default     = null
+}
+
+variable "rule" {
+  description = "The rule blocks used to identify the web requests that you want to `allow`, `block`, or `count`. See Rules below for details."
+  type        = any
+  default     = null
+}
@@ -0,0 +1,137 @@
+package hclgen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/StateOfDenial/tfpd/internal/docs"
+)
+
+type Module struct {
+	Variables string
+	Main      string
+	Outputs   string
+}
+
+var reservedVariableNames = map[string]bool{
+	"source":     true,
+	"version":    true,
+	"providers":  true,
+	"count":      true,
+	"for_each":   true,
+	"lifecycle":  true,
+	"depends_on": true,
+	"locals":     true,
+}
+
+func variableName(name string) string {
+	if reservedVariableNames[name] {
+		return name + "_value"
+	}
+	return name
+}
+
+func (w *writer) variable(name, description string, required bool) {
+	if w.sb.Len() > 0 {
+		w.sb.WriteString("\n")
+	}
+	w.line("", "", fmt.Sprintf("variable %q {", variableName(name)))
+	w.line("", indentUnit, "description = "+quote(description))
+	w.line("", indentUnit, "type        = any")
+	if !required {
+		w.line("", indentUnit, "default     = null")
+	}
+	w.line("", "", "}")
+}
+
+// uniqueArguments drops arguments a doc lists more than once, keeping the
+// first, since Terraform rejects a name defined twice.
+func uniqueArguments(args []docs.Argument) []docs.Argument {
+	var ret []docs.Argument
+	seen := map[string]bool{}
+	for _, a := range args {
+		if !seen[a.Name] {
+			seen[a.Name] = true
+			ret = append(ret, a)
+		}
+	}
+	return ret
+}
+
+func (w *writer) dynamicBody(b *docs.Block, iterator, indent string) {
+	args := uniqueArguments(b.Arguments)
+	width := nameWidth(args)
+	for _, a := range args {
+		value := fmt.Sprintf("try(%s.value.%s, null)", iterator, a.Name)
+		if a.Required {
+			value = fmt.Sprintf("%s.value.%s", iterator, a.Name)
+		}
+		w.line("", indent, fmt.Sprintf("%-*s = %s", width, a.Name, value))
+	}
+	for _, blk := range b.Blocks {
+		// Named after the whole path, so a block nested in one of the same
+		// name doesn't hide its parent's iterator.
+		w.dynamic(blk, fmt.Sprintf("try(%s.value.%s, null)", iterator, blk.Name), iterator+"_"+blk.Name, indent)
+	}
+}
+
+func (w *writer) dynamic(b *docs.Block, source, iterator, indent string) {
+	if !strings.HasSuffix(w.sb.String(), "{\n") {
+		w.sb.WriteString("\n")
+	}
+	w.line("", indent, fmt.Sprintf("dynamic %q {", b.Name))
+	w.line("", indent+indentUnit, fmt.Sprintf("for_each = %s == null ? [] : flatten([%s])", source, source))
+	if iterator != b.Name {
+		w.line("", indent+indentUnit, "iterator = "+iterator)
+	}
+	w.line("", indent+indentUnit, "content {")
+	w.dynamicBody(b, iterator, indent+indentUnit+indentUnit)
+	w.line("", indent+indentUnit, "}")
+	w.line("", indent, "}")
+}
+
+func Wrap(resourceType string, ref docs.Reference) Module {
+	root := ref.Arguments()
+	args := uniqueArguments(root.Arguments)
+
+	variables := writer{}
+	for _, a := range args {
+		variables.variable(a.Name, a.Description, a.Required)
+	}
+	for _, blk := range root.Blocks {
+		variables.variable(blk.Name, blk.Description, blk.Required)
+	}
+
+	main := writer{}
+	main.line("", "", fmt.Sprintf("resource %q \"this\" {", resourceType))
+	width := nameWidth(args)
+	for _, a := range args {
+		main.line("", indentUnit, fmt.Sprintf("%-*s = var.%s", width, a.Name, variableName(a.Name)))
+	}
+	for _, blk := range root.Blocks {
+		main.dynamic(blk, "var."+variableName(blk.Name), blk.Name, indentUnit)
+	}
+	main.line("", "", "}")
+
+	outputs := writer{}
+	seen := map[string]bool{}
+	for _, f := range ref.Kind(docs.KindAttribute) {
+		if len(f.Path) > 0 || f.Block || strings.Contains(f.Name, ".") || seen[f.Name] {
+			continue
+		}
+		seen[f.Name] = true
+		if outputs.sb.Len() > 0 {
+			outputs.sb.WriteString("\n")
+		}
+		outputs.line("", "", fmt.Sprintf("output %q {", f.Name))
+		outputs.line("", indentUnit, "description = "+quote(f.Description))
+		outputs.line("", indentUnit, fmt.Sprintf("value       = %s.this.%s", resourceType, f.Name))
+		outputs.line("", "", "}")
+	}
+
+	return Module{
+		Variables: variables.sb.String(),
+		Main:      main.sb.String(),
+		Outputs:   outputs.sb.String(),
+	}
+}
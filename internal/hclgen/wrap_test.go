@@ -0,0 +1,36 @@
+package hclgen
+
+import (
+	"testing"
+
+	"github.com/StateOfDenial/tfpd/internal/docs"
+)
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		doc, resourceType string
+	}{
+		{"lb_listener", "aws_lb_listener"},
+		{"wafv2_rule_group", "aws_wafv2_rule_group"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.doc, func(t *testing.T) {
+			module := Wrap(tt.resourceType, docReference(t, tt.doc))
+			checkGolden(t, tt.doc+".variables.tf", module.Variables)
+			checkGolden(t, tt.doc+".main.tf", module.Main)
+			checkGolden(t, tt.doc+".outputs.tf", module.Outputs)
+		})
+	}
+}
+
+// A block nested in one of the same name gets its own iterator rather than
+// hiding its parent's.
+func TestWrapNestedBlockNamedLikeParent(t *testing.T) {
+	ref := docs.Reference{Fields: []docs.Field{
+		{Name: "rule", Kind: docs.KindArgument, Block: true},
+		{Name: "name", Kind: docs.KindArgument, Path: []string{"rule"}, Required: true},
+		{Name: "rule", Kind: docs.KindArgument, Path: []string{"rule"}, Block: true},
+		{Name: "name", Kind: docs.KindArgument, Path: []string{"rule", "rule"}},
+	}}
+	checkGolden(t, "nested_same_name.main.tf", Wrap("example_thing", ref).Main)
+}
@@ -0,0 +1,137 @@
+// Package history remembers which docs were picked, so the finder can rank
+// them first next time and `tfpd history` can reopen them.
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const maxEntries = 1000
+
+// maxBoost keeps a much-used doc from outranking a far better match.
+const maxBoost = 64
+
+type Entry struct {
+	Provider string    `json:"provider"`
+	Version  string    `json:"version"`
+	Id       string    `json:"id"`
+	Category string    `json:"category"`
+	Slug     string    `json:"slug"`
+	Query    string    `json:"query"`
+	Time     time.Time `json:"time"`
+}
+
+// Key identifies a doc across provider versions.
+func (e Entry) Key() string {
+	return e.Category + "/" + e.Slug
+}
+
+type History struct {
+	Entries []Entry
+	path    string
+}
+
+func Path() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "tfpd", "history.json"), nil
+}
+
+func Load() (*History, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	h := &History{path: path}
+	body, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(body, &h.Entries); err != nil {
+		return nil, errors.New("could not parse history file " + path + ": " + err.Error())
+	}
+	return h, nil
+}
+
+// Add records entries, dropping the oldest once there are too many.
+func (h *History) Add(entries ...Entry) {
+	h.Entries = append(h.Entries, entries...)
+	if len(h.Entries) > maxEntries {
+		h.Entries = h.Entries[len(h.Entries)-maxEntries:]
+	}
+}
+
+func (h *History) Save() error {
+	body, err := json.Marshal(h.Entries)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(h.path, body, 0644)
+}
+
+// Queries returns the searches that found a doc of provider, newest first
+// and without repeats.
+func (h *History) Queries(provider string) []string {
+	var queries []string
+	seen := map[string]bool{}
+	for i := len(h.Entries) - 1; i >= 0; i-- {
+		e := h.Entries[i]
+		if e.Provider == provider && e.Query != "" && !seen[e.Query] {
+			seen[e.Query] = true
+			queries = append(queries, e.Query)
+		}
+	}
+	return queries
+}
+
+// Boosts scores provider's docs by frecency, keyed by Entry.Key. Each pick
+// counts for more the more recent it is.
+func (h *History) Boosts(provider string) map[string]int {
+	boosts := map[string]int{}
+	for _, e := range h.Entries {
+		if e.Provider == provider {
+			boosts[e.Key()] = min(boosts[e.Key()]+recency(time.Since(e.Time)), maxBoost)
+		}
+	}
+	return boosts
+}
+
+func recency(age time.Duration) int {
+	switch {
+	case age < 24*time.Hour:
+		return 16
+	case age < 7*24*time.Hour:
+		return 8
+	case age < 30*24*time.Hour:
+		return 4
+	default:
+		return 1
+	}
+}
+
+// Recent returns the latest pick of each doc, newest first.
+func (h *History) Recent() []Entry {
+	var recent []Entry
+	seen := map[string]bool{}
+	for i := len(h.Entries) - 1; i >= 0; i-- {
+		e := h.Entries[i]
+		if key := e.Provider + " " + e.Key(); !seen[key] {
+			seen[key] = true
+			recent = append(recent, e)
+		}
+	}
+	return recent
+}
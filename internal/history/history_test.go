@@ -0,0 +1,136 @@
+package history
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func entry(provider, slug, query string, age time.Duration) Entry {
+	return Entry{Provider: provider, Category: "resources", Slug: slug, Query: query, Time: time.Now().Add(-age)}
+}
+
+func TestBoosts(t *testing.T) {
+	day := 24 * time.Hour
+	tests := []struct {
+		name    string
+		entries []Entry
+		want    map[string]int
+	}{
+		{
+			name:    "empty",
+			entries: nil,
+			want:    map[string]int{},
+		},
+		{
+			name: "recent picks count for more",
+			entries: []Entry{
+				entry("aws", "vpc", "", time.Hour),
+				entry("aws", "subnet", "", 3*day),
+				entry("aws", "eip", "", 10*day),
+				entry("aws", "eni", "", 60*day),
+			},
+			want: map[string]int{"resources/vpc": 16, "resources/subnet": 8, "resources/eip": 4, "resources/eni": 1},
+		},
+		{
+			name: "picks add up",
+			entries: []Entry{
+				entry("aws", "vpc", "", time.Hour),
+				entry("aws", "vpc", "", 3*day),
+			},
+			want: map[string]int{"resources/vpc": 24},
+		},
+		{
+			name: "other providers are left out",
+			entries: []Entry{
+				entry("aws", "vpc", "", time.Hour),
+				entry("google", "network", "", time.Hour),
+			},
+			want: map[string]int{"resources/vpc": 16},
+		},
+		{
+			name:    "capped",
+			entries: []Entry{entry("aws", "vpc", "", 0), entry("aws", "vpc", "", 0), entry("aws", "vpc", "", 0), entry("aws", "vpc", "", 0), entry("aws", "vpc", "", 0)},
+			want:    map[string]int{"resources/vpc": maxBoost},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &History{Entries: tt.entries}
+			if got := h.Boosts("aws"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueries(t *testing.T) {
+	h := &History{Entries: []Entry{
+		entry("aws", "vpc", "vpc", 0),
+		entry("aws", "subnet", "", 0),
+		entry("google", "network", "net", 0),
+		entry("aws", "subnet", "sub", 0),
+		entry("aws", "vpc", "vpc", 0),
+	}}
+	want := []string{"vpc", "sub"}
+	if got := h.Queries("aws"); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got := h.Queries("azurerm"); got != nil {
+		t.Errorf("got %q for a provider with no history", got)
+	}
+}
+
+func TestRecent(t *testing.T) {
+	h := &History{Entries: []Entry{
+		entry("aws", "vpc", "first", 0),
+		entry("aws", "subnet", "", 0),
+		entry("google", "vpc", "", 0),
+		entry("aws", "vpc", "second", 0),
+	}}
+	var got []string
+	for _, e := range h.Recent() {
+		got = append(got, e.Provider+" "+e.Slug+" "+e.Query)
+	}
+	want := []string{"aws vpc second", "google vpc ", "aws subnet "}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestAddTrims(t *testing.T) {
+	h := &History{}
+	for i := 0; i < maxEntries+5; i++ {
+		h.Add(Entry{Slug: string(rune('a' + i%26)), Query: string(rune(i))})
+	}
+	if len(h.Entries) != maxEntries {
+		t.Fatalf("got %d entries, want %d", len(h.Entries), maxEntries)
+	}
+	if first := h.Entries[0].Query; first != string(rune(5)) {
+		t.Errorf("oldest kept entry is %q, want the sixth added", first)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	h, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h.Entries) != 0 {
+		t.Fatalf("got %d entries from no file", len(h.Entries))
+	}
+	e := entry("aws", "vpc", "vpc", 0)
+	e.Time = e.Time.Round(time.Second).UTC()
+	h.Add(e)
+	if err := h.Save(); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded.Entries, []Entry{e}) {
+		t.Errorf("got %+v, want %+v", loaded.Entries, []Entry{e})
+	}
+}
@@ -0,0 +1,177 @@
+package lookup
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	h "github.com/StateOfDenial/tfpd/internal/hashicorp"
+	"github.com/StateOfDenial/tfpd/internal/history"
+	"github.com/StateOfDenial/tfpd/pkg/fuzzy"
+)
+
+// PickDocs lets the user pick docs of version of providerName, unless
+// opts.Resource names one exactly.
+func PickDocs(hashiClient *h.Client, providerName string, version h.Version, opts Options) ([]h.Resource, error) {
+	opts.Resource = DocSlug(providerName, opts.Resource)
+	resource := opts.Resource
+	fetch := fetchResources(hashiClient, version.Id)
+	hist := loadHistory()
+	finder := docFinder(hashiClient, providerName, hist, opts)
+
+	var chosen []h.Resource
+	query := resource
+	if resource != "" && !opts.Multi {
+		// Looking for an exact match means waiting for the whole list.
+		resources, err := collect(fetch)
+		if err != nil {
+			return nil, err
+		}
+		chosen = exactDoc(resources, resource, opts.Category)
+		finder.SetItems(resources)
+	} else {
+		items, err := streamItems(fetch)
+		finder.StreamItems(items).SetStreamErr(err)
+	}
+	if chosen == nil {
+		found, err := findResources(finder, opts.Multi)
+		if err != nil {
+			return nil, err
+		}
+		chosen, query = found, finder.LastQuery()
+	}
+	recordDocs(hist, providerName, version, query, chosen)
+	return chosen, nil
+}
+
+// loadHistory reads the history for ranking docs. Not being able to only
+// costs the ranking, so it warns and returns nil rather than failing.
+func loadHistory() *history.History {
+	hist, err := history.Load()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "warning: "+err.Error())
+		return nil
+	}
+	return hist
+}
+
+// docFinder sets up a finder over providerName's docs, ranked by how often
+// they've been picked before if hist isn't nil. Its items are left to the
+// caller.
+func docFinder(hashiClient *h.Client, providerName string, hist *history.History, opts Options) *fuzzy.Finder[h.Resource] {
+	var boosts map[string]int
+	var queries []string
+	if hist != nil {
+		boosts, queries = hist.Boosts(providerName), docQueries(hist.Queries(providerName))
+	}
+	used := usedDocs(providerName)
+
+	finder := fuzzy.New[h.Resource](nil).
+		SetColumns(func(r h.Resource) []string {
+			a := r.Attributes
+			marker := ""
+			if used[docEntry(r).Key()] {
+				marker = "used"
+			}
+			return []string{a.Slug, a.Category, a.Subcategory, marker}
+		}, 1, 0).
+		SetCategories(func(r h.Resource) string { return r.Attributes.Category }).
+		SetActiveCategory(opts.Category).
+		SetQuery(opts.Resource).
+		SetSelectOne(true).
+		SetMouse(opts.Mouse).
+		SetBoost(func(r h.Resource) int { return boosts[docEntry(r).Key()] }).
+		SetHistory(queries).
+		SetMarkdownPreview(func(r h.Resource) string { return DocPreview(hashiClient, r.Id) })
+	if opts.Tree {
+		finder.SetGroups(func(r h.Resource) string { return r.Attributes.Subcategory })
+	}
+	return finder
+}
+
+// docQueries updates saved queries for docs matching on "category slug".
+// They used to match "category: slug", and a colon can't match anything now.
+func docQueries(saved []string) []string {
+	var queries []string
+	seen := map[string]bool{}
+	for _, q := range saved {
+		q = strings.Join(strings.Fields(strings.ReplaceAll(q, ":", " ")), " ")
+		if !seen[q] {
+			seen[q] = true
+			queries = append(queries, q)
+		}
+	}
+	return queries
+}
+
+// exactDoc finds the doc for resource in category, or in "resources" if no
+// category is given.
+func exactDoc(resources []h.Resource, resource, category string) []h.Resource {
+	if category == "" {
+		category = "resources"
+	}
+	for _, r := range resources {
+		if r.Attributes.Category == category && r.Attributes.Slug == resource {
+			return []h.Resource{r}
+		}
+	}
+	return nil
+}
+
+// recordDocs adds chosen to the history, found by searching for query. A
+// history that couldn't be saved is only warned about.
+func recordDocs(hist *history.History, providerName string, version h.Version, query string, chosen []h.Resource) {
+	if hist == nil {
+		return
+	}
+	for _, r := range chosen {
+		e := docEntry(r)
+		e.Provider, e.Version, e.Query, e.Time = providerName, version.String(), query, time.Now()
+		hist.Add(e)
+	}
+	if err := hist.Save(); err != nil {
+		fmt.Fprintln(os.Stderr, "warning: "+err.Error())
+	}
+}
+
+var blockKeywords = map[string]string{
+	"resources":           "resource",
+	"data-sources":        "data",
+	"ephemeral-resources": "ephemeral",
+}
+
+// usedDocs finds which of providerName's docs describe blocks declared in
+// the current directory, keyed like history entries. Blocks in a .tf file
+// that can't be read aren't marked.
+func usedDocs(providerName string) map[string]bool {
+	used := map[string]bool{}
+	blocks, err := h.ProjectBlocks(".")
+	if err != nil {
+		return used
+	}
+	short := shortName(providerName)
+	for category, keyword := range blockKeywords {
+		for block := range blocks {
+			if slug, ok := strings.CutPrefix(block, keyword+" "+short+"_"); ok {
+				used[history.Entry{Category: category, Slug: slug}.Key()] = true
+			}
+		}
+	}
+	return used
+}
+
+func findResources(finder *fuzzy.Finder[h.Resource], multi bool) ([]h.Resource, error) {
+	if multi {
+		return finder.FindMulti()
+	}
+	chosen, err := finder.Find()
+	if err != nil {
+		return nil, err
+	}
+	return []h.Resource{chosen}, nil
+}
+
+func docEntry(r h.Resource) history.Entry {
+	return history.Entry{Id: r.Id, Category: r.Attributes.Category, Slug: r.Attributes.Slug}
+}
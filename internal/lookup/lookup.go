@@ -0,0 +1,228 @@
+// Package lookup finds provider docs on the registry, taking the user
+// through picking a provider, a version and docs in finders as needed.
+package lookup
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/StateOfDenial/tfpd/internal/docs"
+	h "github.com/StateOfDenial/tfpd/internal/hashicorp"
+	"github.com/StateOfDenial/tfpd/internal/tui"
+	"github.com/StateOfDenial/tfpd/pkg/fuzzy"
+)
+
+// Options narrow down which docs to look up. Whatever they leave open is
+// picked in a finder. Resource may be a doc slug or a type as written in
+// configuration, e.g. lb_listener or aws_lb_listener.
+type Options struct {
+	Provider string
+	Version  string
+	Resource string
+	Category string
+	Multi    bool
+	Tree     bool
+	Mouse    bool
+}
+
+// Categories are the kinds of doc the registry files provider docs under.
+var Categories = []string{"resources", "data-sources", "ephemeral-resources", "functions", "guides", "overview"}
+
+// CheckCategory fails unless category is empty or one of Categories, so a
+// typo doesn't open the finder on an empty tab.
+func CheckCategory(category string) error {
+	if category == "" || slices.Contains(Categories, category) {
+		return nil
+	}
+	return errors.New("unknown category " + category + ", expected one of " + strings.Join(Categories, ", "))
+}
+
+func filter[T any](ss []T, test func(T) bool) (ret []T) {
+	for _, s := range ss {
+		if test(s) {
+			ret = append(ret, s)
+		}
+	}
+	return ret
+}
+
+func NewClient() *h.Client {
+	return h.NewClient().SetBaseUrl("http://registry.terraform.io/v2/")
+}
+
+// LockedProviders reads the providers from the lock file of the current
+// directory or the nearest one above it.
+func LockedProviders() ([]h.TerraformProvider, error) {
+	var lockProviders []h.TerraformProvider
+	lockFile, err := h.DiscoverLockFile()
+	if err != nil {
+		return lockProviders, errors.New("Could not find a lock file")
+	}
+	lockProviders = h.GetProvidersFromLockFile(lockFile)
+	if len(lockProviders) == 0 {
+		return lockProviders, errors.New("Found no searchable providers in lock file")
+	}
+	return lockProviders, nil
+}
+
+// PickProvider lets the user pick one of providers, returning its name and
+// version.
+func PickProvider(providers []h.TerraformProvider, initProvider string, mouse bool) (string, string, error) {
+	switch {
+	case len(providers) > 1:
+		chosen, err := providerFinder(providers, initProvider, mouse).Find()
+		if err != nil {
+			return "", "", err
+		}
+		return chosen.Name, chosen.Version, nil
+	case len(providers) == 1:
+		return providers[0].Name, providers[0].Version, nil
+	default:
+		return "", "", errors.New("No providers list to process")
+	}
+}
+
+func providerFinder(providers []h.TerraformProvider, query string, mouse bool) *fuzzy.Finder[h.TerraformProvider] {
+	return fuzzy.New[h.TerraformProvider](nil).
+		SetMouse(mouse).
+		SetColumns(func(p h.TerraformProvider) []string { return []string{p.Name, p.Version, p.Host} }, 0).
+		SetQuery(query).
+		SetSelectOne(true).
+		SetItems(providers)
+}
+
+// shortName is the name providerName's types start with, e.g. aws for
+// hashicorp/aws.
+func shortName(providerName string) string {
+	return providerName[strings.LastIndex(providerName, "/")+1:]
+}
+
+// DocSlug is the slug of resourceType's doc, its name without the prefix of
+// providerName, e.g. lb_listener for aws_lb_listener from hashicorp/aws.
+// Anything else is taken to be a slug already, so lb_listener stays as is.
+func DocSlug(providerName, resourceType string) string {
+	return strings.TrimPrefix(resourceType, shortName(providerName)+"_")
+}
+
+// fetcher reads a list from the registry, calling each with its items as
+// they arrive.
+type fetcher[T any] func(each func(T)) error
+
+// streamItems runs fetch in the background so a finder can open, and fill
+// up, while the registry is still answering. The returned func reports
+// whether fetch failed, once the channel is closed.
+func streamItems[T any](fetch fetcher[T]) (<-chan T, func() error) {
+	items := make(chan T)
+	var err error
+	go func() {
+		defer close(items)
+		err = fetch(func(t T) { items <- t })
+	}()
+	return items, func() error { return err }
+}
+
+// collect waits for the whole list fetch reads.
+func collect[T any](fetch fetcher[T]) ([]T, error) {
+	var items []T
+	err := fetch(func(t T) { items = append(items, t) })
+	return items, err
+}
+
+func fetchVersions(hashiClient *h.Client, providerName string) fetcher[h.Version] {
+	return func(each func(h.Version)) error {
+		return hashiClient.StreamProviderVersions(providerName, each)
+	}
+}
+
+func fetchResources(hashiClient *h.Client, versionId string) fetcher[h.Resource] {
+	return func(each func(h.Resource)) error {
+		return hashiClient.StreamProviderVersionResources(versionId, each)
+	}
+}
+
+func PickVersion(hashiClient *h.Client, providerName, query string, mouse bool) (h.Version, error) {
+	items, err := streamItems(fetchVersions(hashiClient, providerName))
+	return fuzzy.New(h.Version.String).
+		SetMouse(mouse).
+		SetQuery(query).
+		SetSelectOne(true).
+		StreamItems(items).
+		SetStreamErr(err).
+		Find()
+}
+
+func MatchVersion(hashiClient *h.Client, providerName, version string) (h.Version, error) {
+	all, err := collect(fetchVersions(hashiClient, providerName))
+	if err != nil {
+		return h.Version{}, err
+	}
+	filterTest := func(v h.Version) bool { return v.Attributes.Version == version }
+	versions := filter(all, filterTest)
+	if len(versions) == 0 {
+		return h.Version{}, errors.New("could not find version " + version + " of " + providerName)
+	}
+	return versions[0], nil
+}
+
+// FindDocs takes the user through the finders opts leave open, up to
+// picking docs.
+func FindDocs(opts Options, hashiClient *h.Client) ([]h.Resource, error) {
+	s := newSession(opts, hashiClient, false)
+	if err := s.run(pickingDocs); err != nil {
+		return nil, err
+	}
+	return s.docs, nil
+}
+
+func FindDoc(opts Options, hashiClient *h.Client) (h.Resource, error) {
+	opts.Multi = false
+	chosen, err := FindDocs(opts, hashiClient)
+	if err != nil {
+		return h.Resource{}, err
+	}
+	return chosen[0], nil
+}
+
+// GetDoc finds one doc and fetches its content.
+func GetDoc(opts Options) (h.Resource, string, error) {
+	hashiClient := NewClient()
+	chosen, err := FindDoc(opts, hashiClient)
+	if err != nil {
+		return h.Resource{}, "", err
+	}
+	content, err := hashiClient.GetResourceDoc(chosen.Id)
+	if err != nil {
+		return h.Resource{}, "", err
+	}
+	return chosen, content, nil
+}
+
+// View finds docs and opens them in the doc viewer. Esc or Backspace steps
+// back through the finders that were shown, keeping their search and cursor.
+func View(opts Options, hashiClient *h.Client) error {
+	return newSession(opts, hashiClient, true).run(viewing)
+}
+
+// DocTabs fetches each of chosen for the doc viewer.
+func DocTabs(hashiClient *h.Client, chosen []h.Resource) ([]tui.Tab, error) {
+	tabs := make([]tui.Tab, len(chosen))
+	for i, c := range chosen {
+		content, err := hashiClient.GetResourceDoc(c.Id)
+		if err != nil {
+			return nil, err
+		}
+		tabs[i] = tui.Tab{Title: c.Attributes.Slug, Content: content}
+	}
+	return tabs, nil
+}
+
+// DocPreview trims the doc with id for a finder's preview pane, or says why
+// it couldn't be fetched.
+func DocPreview(hashiClient *h.Client, id string) string {
+	content, err := hashiClient.GetResourceDoc(id)
+	if err != nil {
+		return "Couldn't fetch the doc: " + err.Error()
+	}
+	return docs.Preview(content)
+}
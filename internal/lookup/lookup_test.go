@@ -0,0 +1,25 @@
+package lookup
+
+import "testing"
+
+func TestDocSlug(t *testing.T) {
+	tests := []struct {
+		provider     string
+		resourceType string
+		want         string
+	}{
+		{"hashicorp/aws", "aws_lb_listener", "lb_listener"},
+		{"hashicorp/aws", "lb_listener", "lb_listener"},
+		{"registry.terraform.io/hashicorp/google", "google_cloud_run_v2_job", "cloud_run_v2_job"},
+		{"hashicorp/google", "google-beta_thing", "google-beta_thing"},
+		{"hashicorp/aws", "awscc_s3_bucket", "awscc_s3_bucket"},
+		{"", "aws_instance", "aws_instance"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.provider+" "+tt.resourceType, func(t *testing.T) {
+			if got := DocSlug(tt.provider, tt.resourceType); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
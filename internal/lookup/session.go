@@ -0,0 +1,272 @@
+package lookup
+
+import (
+	"errors"
+	"sync"
+
+	h "github.com/StateOfDenial/tfpd/internal/hashicorp"
+	"github.com/StateOfDenial/tfpd/internal/tui"
+	"github.com/StateOfDenial/tfpd/pkg/fuzzy"
+)
+
+type stage int
+
+const (
+	pickingProvider stage = iota
+	pickingVersion
+	pickingDocs
+	viewing
+)
+
+// left is how a finder was left when something was picked in it, so going
+// back to it can reopen it the same way.
+type left struct {
+	picked bool
+	query  string
+	tab    string
+}
+
+// listing keeps the list last fetched, so a finder reopened on it doesn't
+// have to ask the registry again.
+type listing[T any] struct {
+	mu    sync.Mutex
+	key   string
+	items []T
+}
+
+func (l *listing[T]) get(key string) ([]T, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.items, l.items != nil && l.key == key
+}
+
+// fetcher wraps fetch to keep what it reads, once it has read it all.
+func (l *listing[T]) fetcher(key string, fetch fetcher[T]) fetcher[T] {
+	return func(each func(T)) error {
+		var items []T
+		err := fetch(func(t T) {
+			items = append(items, t)
+			each(t)
+		})
+		if err != nil {
+			return err
+		}
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.key, l.items = key, items
+		return nil
+	}
+}
+
+// session steps through picking a provider, a version and docs, and then
+// viewing them, skipping whatever the lock file or flags settle. With back
+// set, Esc or Backspace goes back a step, reopening the finder before it
+// with its query and cursor as they were.
+type session struct {
+	client    *h.Client
+	opts      Options
+	back      bool
+	stages    []stage
+	providers []h.TerraformProvider
+	provider  h.TerraformProvider
+	version   h.Version
+	docs      []h.Resource
+	left      map[stage]left
+	versions  listing[h.Version]
+	resources listing[h.Resource]
+}
+
+func newSession(opts Options, hashiClient *h.Client, back bool) *session {
+	s := &session{client: hashiClient, opts: opts, back: back, left: map[stage]left{}}
+	providers, err := LockedProviders()
+	switch {
+	case err == nil && len(providers) > 1:
+		s.providers = providers
+		s.stages = append(s.stages, pickingProvider)
+	case err == nil:
+		s.provider = providers[0]
+	case opts.Provider == "":
+		s.stages = append(s.stages, pickingProvider)
+	default:
+		s.provider.Name = opts.Provider
+	}
+	if err != nil {
+		s.stages = append(s.stages, pickingVersion)
+	}
+	s.stages = append(s.stages, pickingDocs, viewing)
+	return s
+}
+
+// run takes the user through each stage up to last. Going back from the
+// first one aborts.
+func (s *session) run(last stage) error {
+	for i := 0; ; {
+		at := s.stages[i]
+		err := s.step(at, s.back && i > 0)
+		switch {
+		case errors.Is(err, tui.ErrBack):
+			if i == 0 {
+				return tui.ErrAborted
+			}
+			i--
+		case err != nil:
+			return err
+		case at == last:
+			return nil
+		default:
+			i++
+		}
+	}
+}
+
+func (s *session) step(at stage, back bool) error {
+	switch at {
+	case pickingProvider:
+		return s.pickProvider()
+	case pickingVersion:
+		return s.pickVersion(back)
+	case pickingDocs:
+		return s.pickDocs(back)
+	default:
+		return s.view(back)
+	}
+}
+
+// picked notes how the finder at was left. If what was picked changed, the
+// later finders list something else now, so how they were left is dropped.
+func (s *session) picked(at stage, changed bool, query, tab string) {
+	if changed {
+		for later := at + 1; later <= viewing; later++ {
+			delete(s.left, later)
+		}
+	}
+	s.left[at] = left{picked: true, query: query, tab: tab}
+}
+
+// reopen puts finder back as l was left, with the cursor on the item at
+// reports. It must be given its items with SetItems.
+func reopen[T any](finder *fuzzy.Finder[T], l left, at func(T) bool) {
+	finder.SetQuery(l.query).SetActiveCategory(l.tab).SetSelectOne(false).SetCursor(at)
+}
+
+// pickProvider is always the first stage, so it can't go back.
+func (s *session) pickProvider() error {
+	l := s.left[pickingProvider]
+	var chosen h.TerraformProvider
+	query := ""
+	if s.providers == nil {
+		name, err := tui.Prompt("Enter in a provider to look for: e.g. 'hashicorp/google'", l.query)
+		if err != nil {
+			return err
+		}
+		chosen.Name, query = name, name
+	} else {
+		finder := providerFinder(s.providers, s.opts.Provider, s.opts.Mouse)
+		if l.picked {
+			reopen(finder, l, func(p h.TerraformProvider) bool { return p == s.provider })
+		}
+		var err error
+		chosen, err = finder.Find()
+		if err != nil {
+			return err
+		}
+		query = finder.LastQuery()
+	}
+	if chosen != s.provider {
+		s.version = h.Version{}
+	}
+	s.picked(pickingProvider, chosen != s.provider, query, "")
+	s.provider = chosen
+	return nil
+}
+
+func (s *session) pickVersion(back bool) error {
+	name := s.provider.Name
+	finder := fuzzy.New(h.Version.String).SetBack(back).SetMouse(s.opts.Mouse)
+	if items, ok := s.versions.get(name); ok {
+		finder.SetItems(items)
+	} else {
+		items, err := streamItems(s.versions.fetcher(name, fetchVersions(s.client, name)))
+		finder.StreamItems(items).SetStreamErr(err)
+	}
+	if l := s.left[pickingVersion]; l.picked {
+		reopen(finder, l, func(v h.Version) bool { return v.Id == s.version.Id })
+	} else {
+		finder.SetQuery(s.opts.Version).SetSelectOne(true)
+	}
+
+	chosen, err := finder.Find()
+	if err != nil {
+		return err
+	}
+	s.picked(pickingVersion, chosen.Id != s.version.Id, finder.LastQuery(), "")
+	s.version = chosen
+	return nil
+}
+
+func (s *session) pickDocs(back bool) error {
+	if s.version.Id == "" {
+		v, err := MatchVersion(s.client, s.provider.Name, s.provider.Version)
+		if err != nil {
+			return err
+		}
+		s.version = v
+	}
+	opts := s.opts
+	opts.Resource = DocSlug(s.provider.Name, opts.Resource)
+	hist := loadHistory()
+	finder := docFinder(s.client, s.provider.Name, hist, opts).SetBack(back)
+
+	id := s.version.Id
+	fetch := s.resources.fetcher(id, fetchResources(s.client, id))
+	items, cached := s.resources.get(id)
+	resource := opts.Resource
+	l := s.left[pickingDocs]
+	var chosen []h.Resource
+	query, tab := resource, s.opts.Category
+	switch {
+	case l.picked && cached:
+		finder.SetItems(items)
+		reopen(finder, l, func(r h.Resource) bool { return r.Id == s.docs[0].Id })
+	case resource != "" && !s.opts.Multi:
+		// Looking for an exact match means waiting for the whole list.
+		if !cached {
+			fetched, err := collect(fetch)
+			if err != nil {
+				return err
+			}
+			items = fetched
+		}
+		chosen = exactDoc(items, resource, s.opts.Category)
+		finder.SetItems(items)
+	case cached:
+		finder.SetItems(items)
+	default:
+		items, err := streamItems(fetch)
+		finder.StreamItems(items).SetStreamErr(err)
+	}
+	if chosen == nil {
+		found, err := findResources(finder, s.opts.Multi)
+		if err != nil {
+			return err
+		}
+		chosen, query, tab = found, finder.LastQuery(), finder.LastCategory()
+	}
+
+	s.picked(pickingDocs, false, query, tab)
+	s.docs = chosen
+	recordDocs(hist, s.provider.Name, s.version, query, chosen)
+	return nil
+}
+
+func (s *session) view(back bool) error {
+	tabs, err := DocTabs(s.client, s.docs)
+	if err != nil {
+		return err
+	}
+	m, err := tui.NewMDViewerTabs(tabs)
+	if err != nil {
+		return err
+	}
+	return m.SetBack(back).SetMouse(s.opts.Mouse).Display()
+}
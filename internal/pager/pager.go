@@ -0,0 +1,80 @@
+package pager
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+func command(cmdLine string, args ...string) (*exec.Cmd, error) {
+	fields := strings.Fields(cmdLine)
+	if len(fields) == 0 {
+		return nil, errors.New("no command configured")
+	}
+	cmd := exec.Command(fields[0], append(fields[1:], args...)...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd, nil
+}
+
+func Page(content, pagerCmd string) error {
+	cmd, err := command(pagerCmd)
+	if err != nil {
+		return err
+	}
+	cmd.Stdin = strings.NewReader(content)
+	return cmd.Run()
+}
+
+func readOnlyFlags(editorCmd string) []string {
+	fields := strings.Fields(editorCmd)
+	if len(fields) == 0 {
+		return nil
+	}
+	switch filepath.Base(fields[0]) {
+	case "vi", "vim", "nvim", "view":
+		return []string{"-R"}
+	case "nano":
+		return []string{"-v"}
+	}
+	return nil
+}
+
+// tempName makes name safe to put in a temp file's pattern, which can't hold
+// a path separator and takes its last * for the random part.
+func tempName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune("*/"+string(os.PathSeparator), r) {
+			return '_'
+		}
+		return r
+	}, name)
+}
+
+func Edit(content, name, editorCmd string) error {
+	f, err := os.CreateTemp("", "tfpd-*-"+tempName(name)+".md")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(content); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(f.Name(), 0444); err != nil {
+		return err
+	}
+
+	args := append(readOnlyFlags(editorCmd), f.Name())
+	cmd, err := command(editorCmd, args...)
+	if err != nil {
+		return err
+	}
+	cmd.Stdin = os.Stdin
+	return cmd.Run()
+}
@@ -0,0 +1,35 @@
+package tui
+
+import "slices"
+
+// SetBack makes Esc, or Backspace on an empty prompt, return ErrBack rather
+// than ErrAborted, for a finder that is one step of several. Ctrl-C still
+// aborts.
+func (ff *FuzzyFinder) SetBack(back bool) *FuzzyFinder {
+	ff.back = back
+	return ff
+}
+
+// SetCursorItem opens the finder with the cursor on the item with id, as
+// when coming back to it. It has no effect on items that are streamed in.
+func (ff *FuzzyFinder) SetCursorItem(id int) *FuzzyFinder {
+	ff.resume = id
+	return ff
+}
+
+func (ff *FuzzyFinder) restoreCursor() {
+	if ff.resume < 0 || ff.resume >= len(ff.SearchList) {
+		return
+	}
+	if ff.tree.enabled {
+		ff.tree.expanded[ff.SearchList[ff.resume].group] = true
+		ff.showMatches()
+	}
+	i := slices.IndexFunc(ff.FilteredList, func(item FilteredItem) bool {
+		return item.Valid && item.Id == ff.resume
+	})
+	if i >= 0 {
+		ff.cursor = i
+		ff.setListContent()
+	}
+}
@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// finderCategories splits the list into tabs by Item.Category, with an
+// "all" tab first and the rest sorted so Alt-digits always pick the same
+// tab. An empty active category is the "all" tab.
+type finderCategories struct {
+	names  []string
+	active string
+	counts map[string]int
+}
+
+// SetCategory opens the finder on the tab for category, or on every item
+// if category is empty.
+func (ff *FuzzyFinder) SetCategory(category string) *FuzzyFinder {
+	ff.categories.active = category
+	ff.addCategory(category)
+	return ff
+}
+
+// Category returns the tab the finder was on when it closed, or "" for the
+// "all" tab.
+func (ff *FuzzyFinder) Category() string {
+	return ff.categories.active
+}
+
+func (ff *FuzzyFinder) addCategory(category string) {
+	if category == "" {
+		return
+	}
+	if i, found := slices.BinarySearch(ff.categories.names, category); !found {
+		ff.categories.names = slices.Insert(ff.categories.names, i, category)
+	}
+}
+
+// showMatches works out what to list from the matched items.
+func (ff *FuzzyFinder) showMatches() {
+	ff.showCategory()
+	ff.shown = len(ff.FilteredList)
+	if ff.tree.enabled {
+		ff.FilteredList = ff.groupTree(ff.FilteredList)
+	}
+}
+
+// showCategory narrows the matched items down to the active tab, counting
+// the matches in every tab as it goes.
+func (ff *FuzzyFinder) showCategory() {
+	c := &ff.categories
+	if len(c.names) == 0 {
+		ff.FilteredList = ff.matched
+		return
+	}
+	c.counts = map[string]int{}
+	if c.active == "" {
+		ff.FilteredList = ff.matched
+	} else {
+		ff.FilteredList = make([]FilteredItem, 0, len(ff.matched))
+	}
+	for _, item := range ff.matched {
+		c.counts[item.category]++
+		if c.active != "" && item.category == c.active {
+			ff.FilteredList = append(ff.FilteredList, item)
+		}
+	}
+	ff.setCategoryTitle()
+}
+
+func (ff *FuzzyFinder) setCategoryTitle() {
+	c := ff.categories
+	tabs := []string{fmt.Sprintf("all %d", len(ff.matched))}
+	for _, name := range c.names {
+		tabs = append(tabs, fmt.Sprintf("%s %d", name, c.counts[name]))
+	}
+	active := slices.Index(c.names, c.active) + 1
+	tabs[active] = "[" + tabs[active] + "]"
+	ff.Renderer.Sections[listPos].Title = []rune(" " + strings.Join(tabs, " | ") + " ")
+}
+
+// switchCategory moves to the tab at index i, where 0 is "all".
+func (ff *FuzzyFinder) switchCategory(i int) {
+	c := &ff.categories
+	if len(c.names) == 0 || i < 0 || i > len(c.names) {
+		return
+	}
+	c.active = ""
+	if i > 0 {
+		c.active = c.names[i-1]
+	}
+	ff.cursor, ff.offset = 0, 0
+	ff.showMatches()
+	ff.setListContent()
+}
+
+func (ff *FuzzyFinder) cycleCategory(by int) {
+	tabs := len(ff.categories.names) + 1
+	current := slices.Index(ff.categories.names, ff.categories.active) + 1
+	ff.switchCategory(((current+by)%tabs + tabs) % tabs)
+}
@@ -0,0 +1,84 @@
+package tui
+
+import (
+	"slices"
+
+	"github.com/mattn/go-runewidth"
+)
+
+const columnGap = "  "
+
+// finderColumns lines up items with Fields. Columns only ever widen, so the
+// list doesn't shift about as items stream in.
+type finderColumns struct {
+	match  []int
+	widths []int
+}
+
+// SetColumns matches items with Fields against the fields at the given
+// indexes, in that order, instead of all of them. Call it before adding
+// items.
+func (ff *FuzzyFinder) SetColumns(match ...int) *FuzzyFinder {
+	ff.columns.match = match
+	return ff
+}
+
+// columnSearch joins the fields to match on into item's search text,
+// noting where each one starts in it.
+func columnSearch(fields [][]rune, match []int) ([]rune, []int) {
+	if match == nil {
+		for i := range fields {
+			match = append(match, i)
+		}
+	}
+	starts := make([]int, len(fields))
+	for i := range starts {
+		starts[i] = -1
+	}
+	var search []rune
+	for _, i := range match {
+		if i < 0 || i >= len(fields) {
+			continue
+		}
+		if len(search) > 0 {
+			search = append(search, ' ')
+		}
+		starts[i] = len(search)
+		search = append(search, fields[i]...)
+	}
+	return search, starts
+}
+
+func (c *finderColumns) fit(items []FuzzyContentItem) {
+	for _, item := range items {
+		for i, f := range item.fields {
+			if i == len(c.widths) {
+				c.widths = append(c.widths, 0)
+			}
+			c.widths[i] = min(max(c.widths[i], runewidth.StringWidth(string(f))), maxColumnWidth)
+		}
+	}
+}
+
+// format lays item's fields out in columns, moving its match highlights
+// from the search text onto them.
+func (c *finderColumns) format(item FilteredItem) ([]rune, []int) {
+	var line []rune
+	var highlights []int
+	for i, f := range item.fields {
+		cell := []rune(runewidth.Truncate(string(f), c.widths[i], "…"))
+		if start := item.fieldStarts[i]; item.highlight && start >= 0 {
+			for _, p := range item.Positions {
+				if p >= start && p-start < len(f) && p-start < len(cell) && cell[p-start] == f[p-start] {
+					highlights = append(highlights, len(line)+p-start)
+				}
+			}
+		}
+		if i < len(item.fields)-1 {
+			cell = []rune(runewidth.FillRight(string(cell), c.widths[i]) + columnGap)
+		}
+		line = append(line, cell...)
+	}
+	slices.Sort(highlights)
+	return line, highlights
+}
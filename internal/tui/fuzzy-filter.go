@@ -0,0 +1,200 @@
+package tui
+
+import (
+	"cmp"
+	"context"
+	"runtime"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+const minFilterChunk = 2048
+const cancelCheckInterval = 256
+const postRetryInterval = 10 * time.Millisecond
+
+type filterPass struct {
+	generation int
+	query      []rune
+	items      []FilteredItem
+}
+
+func isLiteralSubsequence(sub, of []rune) bool {
+	j := 0
+	for i := 0; i < len(of) && j < len(sub); i++ {
+		if of[i] == sub[j] {
+			j++
+		}
+	}
+	return j == len(sub)
+}
+
+// rankOf packs the sort order (higher score, then shorter text, then
+// original position) into one integer so sorting never has to follow the
+// item pointer.
+func rankOf(score, length, id int) uint64 {
+	inverted := uint64(min(max(1<<19-score, 0), 1<<20-1))
+	return inverted<<44 | uint64(min(length, 1<<12-1))<<32 | uint64(uint32(id))
+}
+
+func compareItems(a, b FilteredItem) int {
+	return cmp.Compare(a.rank, b.rank)
+}
+
+func mergeSorted(runs [][]FilteredItem, total int) []FilteredItem {
+	if len(runs) == 1 {
+		return runs[0]
+	}
+	items := make([]FilteredItem, 0, total)
+	for len(items) < total {
+		next := -1
+		for i, run := range runs {
+			if len(run) > 0 && (next < 0 || run[0].rank < runs[next][0].rank) {
+				next = i
+			}
+		}
+		items = append(items, runs[next][0])
+		runs[next] = runs[next][1:]
+	}
+	return items
+}
+
+// ranked reports whether matches need sorting. Without a query they keep
+// their original order, unless some items are boosted to the front.
+func ranked(q query, items []FilteredItem) bool {
+	return !q.empty() || slices.ContainsFunc(items, func(item FilteredItem) bool { return item.boost != 0 })
+}
+
+func filterChunk(ctx context.Context, chunk []FilteredItem, q query, sorted bool) ([]FilteredItem, bool) {
+	var mt matcher
+	ret := make([]FilteredItem, 0, len(chunk)/4)
+	for i := range chunk {
+		if i%cancelCheckInterval == 0 && ctx.Err() != nil {
+			return nil, false
+		}
+		item := chunk[i]
+		match, valid := mt.matchQuery(item.text, q)
+		if valid {
+			item.Score = match.Score
+			item.Positions = match.Positions
+			length := len(item.text.runes)
+			if q.empty() {
+				length = 0
+			}
+			item.rank = rankOf(match.Score+item.boost, length, item.Id)
+			ret = append(ret, item)
+		}
+	}
+	if sorted {
+		slices.SortFunc(ret, compareItems)
+	}
+	return ret, true
+}
+
+// filterItems scores candidates against query across all CPUs. It returns
+// false if ctx was cancelled before every chunk finished.
+func filterItems(ctx context.Context, candidates []FilteredItem, input []rune) ([]FilteredItem, bool) {
+	q := parseQuery(input)
+	sorted := ranked(q, candidates)
+	workers := runtime.GOMAXPROCS(0)
+	size := max((len(candidates)+workers-1)/workers, minFilterChunk)
+
+	var chunks [][]FilteredItem
+	for start := 0; start < len(candidates); start += size {
+		chunks = append(chunks, candidates[start:min(start+size, len(candidates))])
+	}
+	results := make([][]FilteredItem, len(chunks))
+	completed := make([]bool, len(chunks))
+
+	var wg sync.WaitGroup
+	for i := range chunks {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], completed[i] = filterChunk(ctx, chunks[i], q, sorted)
+		}(i)
+	}
+	wg.Wait()
+
+	total := 0
+	for i := range results {
+		if !completed[i] {
+			return nil, false
+		}
+		total += len(results[i])
+	}
+	if sorted {
+		return mergeSorted(results, total), ctx.Err() == nil
+	}
+	items := make([]FilteredItem, 0, total)
+	for _, r := range results {
+		items = append(items, r...)
+	}
+	return items, ctx.Err() == nil
+}
+
+// candidates narrows the search to the last result set when the new query
+// can only match a subset of what the previous one did.
+func (ff *FuzzyFinder) candidates() []FilteredItem {
+	if parseQuery(ff.prompt.text).narrows(parseQuery(ff.filteredQuery)) {
+		return ff.matched
+	}
+	return ff.allItems
+}
+
+func (ff *FuzzyFinder) startFilter() {
+	if ff.cancelFilter != nil {
+		ff.cancelFilter()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	ff.cancelFilter = cancel
+	ff.generation++
+	ff.filtering = true
+
+	pass := &filterPass{
+		generation: ff.generation,
+		query:      append([]rune{}, ff.prompt.text...),
+	}
+	candidates := ff.candidates()
+	screen := ff.Renderer.Screen
+	go func() {
+		items, ok := filterItems(ctx, candidates, pass.query)
+		if !ok {
+			return
+		}
+		pass.items = items
+		postUntilCancelled(ctx, screen, tcell.NewEventInterrupt(pass))
+	}()
+}
+
+// postUntilCancelled retries posting ev while the event queue is full, giving
+// up once ctx is cancelled so the goroutine can't outlive the finder.
+func postUntilCancelled(ctx context.Context, screen tcell.Screen, ev tcell.Event) {
+	for screen.PostEvent(ev) != nil {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(postRetryInterval):
+		}
+	}
+}
+
+func (ff *FuzzyFinder) applyFilter(pass *filterPass) {
+	if pass.generation != ff.generation {
+		return
+	}
+	ff.filtering = false
+	ff.matched = pass.items
+	ff.filteredQuery = pass.query
+	ff.showMatches()
+	ff.cursor, ff.offset = 0, 0
+	ff.setListContent()
+}
+
+func (ff *FuzzyFinder) stopFilter() {
+	if ff.cancelFilter != nil {
+		ff.cancelFilter()
+	}
+}
@@ -0,0 +1,63 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func benchItems(n int) []FilteredItem {
+	kinds := []string{"resource", "data-source", "guide", "function"}
+	items := make([]FilteredItem, n)
+	for i := range items {
+		text := fmt.Sprintf("%s: aws_service%d_resource_%d_config", kinds[i%len(kinds)], i%97, i)
+		items[i] = FilteredItem{FuzzyContentItem: &FuzzyContentItem{Id: i, Valid: true, text: prepareText([]rune(text))}}
+	}
+	return items
+}
+
+// frameBudget is how long filtering 50k items may take for the list to keep
+// up with typing: one frame at 60Hz. Each benchmark reports its time as a
+// fraction of it, in frames/op, so anything over 1 misses the budget.
+const frameBudget = time.Second / 60
+
+func reportFrames(b *testing.B) {
+	b.ReportMetric(float64(b.Elapsed())/float64(b.N)/float64(frameBudget), "frames/op")
+}
+
+func BenchmarkFilterItems(b *testing.B) {
+	ctx := context.Background()
+	input := []rune("aws res cfg")
+	for _, n := range []int{10_000, 50_000, 100_000} {
+		items := benchItems(n)
+		b.Run(fmt.Sprintf("serial/%d", n), func(b *testing.B) {
+			q := parseQuery(input)
+			for i := 0; i < b.N; i++ {
+				filterChunk(ctx, items, q, true)
+			}
+			reportFrames(b)
+		})
+		b.Run(fmt.Sprintf("chunked/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				filterItems(ctx, items, input)
+			}
+			reportFrames(b)
+		})
+		// Adding to a query only rescans what the previous one matched.
+		b.Run(fmt.Sprintf("narrowing/%d", n), func(b *testing.B) {
+			prev := []rune("'service42_")
+			matched, _ := filterItems(ctx, items, prev)
+			ff := FuzzyFinder{allItems: items, matched: matched, filteredQuery: prev}
+			ff.prompt.setText(string(prev) + " cfg")
+			if len(ff.candidates()) != len(matched) {
+				b.Fatal("query didn't narrow the previous results")
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				filterItems(ctx, ff.candidates(), ff.prompt.text)
+			}
+			reportFrames(b)
+		})
+	}
+}
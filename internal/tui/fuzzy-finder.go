@@ -1,9 +1,10 @@
 package tui
 
 import (
-	"log"
-	"os"
-	"regexp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
 
 	"github.com/gdamore/tcell/v2"
 )
@@ -11,19 +12,79 @@ import (
 const textboxPos = 0
 const listPos = 1
 
+var (
+	ErrAborted = errors.New("selection aborted")
+	ErrBack    = errors.New("went back")
+	ErrNoMatch = errors.New("no matching item")
+)
+
+// Item is something to pick from, shown as Display and matched against
+// Search. Match highlights are only drawn when the two are the same. Items
+// with a Category get a tab each for their category in the finder, and in a
+// tree they are listed under a header for their Group. Boost is added to the
+// item's score, and puts it ahead of others when there's no query. Items
+// with Fields are drawn as aligned columns in place of Display, and if
+// Search is empty they match on the fields chosen with SetColumns.
+type Item struct {
+	Display  string
+	Search   string
+	Fields   []string
+	Category string
+	Group    string
+	Boost    int
+}
+
 type FuzzyContentItem struct {
-	Content             string
-	Id                  int
-	LevenshteinDistance int
-	Valid               bool
+	Content     string
+	Id          int
+	Valid       bool
+	text        preparedText
+	display     []rune
+	highlight   bool
+	category    string
+	group       string
+	boost       int
+	fields      [][]rune
+	fieldStarts []int
+}
+
+type FilteredItem struct {
+	*FuzzyContentItem
+	Score     int
+	Positions []int
+	rank      uint64
 }
 
 type FuzzyFinder struct {
-	Renderer     Renderer
-	SearchList   []FuzzyContentItem
-	FilteredList []FuzzyContentItem
-	SearchPos    int
-	SearchInput  []rune
+	Renderer      Renderer
+	SearchList    []FuzzyContentItem
+	FilteredList  []FilteredItem
+	allItems      []FilteredItem
+	matched       []FilteredItem
+	shown         int
+	prompt        lineEditor
+	filteredQuery []rune
+	generation    int
+	cancelFilter  context.CancelFunc
+	multi         bool
+	selected      map[int]bool
+	selectedOrder []int
+	cursor        int
+	offset        int
+	preview       finderPreview
+	stream        *itemStream
+	streamErr     func() error
+	filtering     bool
+	selectOne     bool
+	exitZero      bool
+	touched       bool
+	categories    finderCategories
+	tree          finderTree
+	history       queryHistory
+	clicks        clicker
+	columns       finderColumns
+	back          bool
+	resume        int
 }
 
 func textSectionDimensions(w, h int) (int, int, int, int) {
@@ -42,8 +103,9 @@ func newTextSection(w, h int) *Section {
 		SetStartY(sy).
 		SetEndY(sy)
 
-	s.SetCursor(sx+5, ey-1, Typing)
-	s.Cursor.SetCursorXBoundary(sx+5, ex-5)
+	promptX := sx + contentIndent + len(promptMarker)
+	s.SetCursor(promptX, ey-1, Typing)
+	s.Cursor.SetCursorXBoundary(promptX, ex-5)
 	s.Cursor.SetCursorYBoundary(ey-1, ey-1)
 	return s
 }
@@ -55,55 +117,130 @@ func newListSection(w, h int) *Section {
 		SetEndX(ex).
 		SetStartY(sy).
 		SetEndY(sy)
+	s.HighlightStyle = tcell.StyleDefault.Foreground(tcell.ColorGreen).Bold(true)
 	s.SetCursor(sx+2, ey-1, Selection)
 	s.Cursor.SetCursorXBoundary(sx+2, sx+2)
 	s.Cursor.SetCursorYBoundary(sy+1, ey-1)
 	return s
 }
 
-func NewFuzzyFinder() FuzzyFinder {
-	r := NewRenderer()
+func NewFuzzyFinder() (FuzzyFinder, error) {
+	r, err := NewRenderer()
+	if err != nil {
+		return FuzzyFinder{}, err
+	}
 	x, y := r.Size()
 	textbox := newTextSection(x, y)
-	textbox.Content = append(textbox.Content, []rune{'>', ' '})
+	textbox.Content = append(textbox.Content, promptMarker)
 	list := newListSection(x, y)
 	r.AddSection(textboxPos, *textbox).
 		AddSection(listPos, *list)
+	r.Screen.EnablePaste()
+	r.SetMouse(true)
 	return FuzzyFinder{
-		Renderer:  r,
-		SearchPos: 0,
-	}
+		Renderer: r,
+		history:  queryHistory{pos: -1},
+		resume:   -1,
+	}, nil
+}
+
+// SetMouse turns clicking and scrolling in the finder on or off. It's on
+// unless turned off.
+func (ff *FuzzyFinder) SetMouse(enabled bool) *FuzzyFinder {
+	ff.Renderer.SetMouse(enabled)
+	return ff
 }
 
 func (ff *FuzzyFinder) SetFuzzyItems(in []string) *FuzzyFinder {
-	list := make([]FuzzyContentItem, 0)
-	os.Stderr.WriteString("setting items")
+	items := make([]Item, len(in))
 	for i, s := range in {
-		list = append(list, FuzzyContentItem{
-			Content:             s,
-			LevenshteinDistance: 0,
-			Valid:               true,
-			Id:                  i,
-		})
+		items[i] = Item{Display: s, Search: s}
+	}
+	return ff.SetItems(items)
+}
+
+func (ff *FuzzyFinder) SetItems(in []Item) *FuzzyFinder {
+	list := make([]FuzzyContentItem, 0, len(in))
+	for i, item := range in {
+		list = append(list, newFuzzyItem(item, i, ff.columns.match))
 	}
+	ff.columns.fit(list)
 	ff.SearchList = list
+	ff.allItems = make([]FilteredItem, len(list))
+	for i := range list {
+		ff.allItems[i] = FilteredItem{FuzzyContentItem: &ff.SearchList[i]}
+		ff.addCategory(list[i].category)
+	}
 	return ff
 }
 
-func (ff *FuzzyFinder) FuzzyFindWithInput(initSearch string) int {
-	ff.SearchInput = []rune(initSearch)
+// SetSearchInput starts the search at input.
+func (ff *FuzzyFinder) SetSearchInput(input string) *FuzzyFinder {
+	ff.prompt.setText(input)
 	ff.setTextBoxContent()
-	ff.recalcList()
-	if len(ff.FilteredList) == 1 {
-		ff.Renderer.Screen.Fini()
-		return ff.FilteredList[0].Id
+	return ff
+}
+
+// SetSelectOne makes the finder return straight away if its initial search
+// only matches one item.
+func (ff *FuzzyFinder) SetSelectOne(selectOne bool) *FuzzyFinder {
+	ff.selectOne = selectOne
+	return ff
+}
+
+// SetExitZero makes the finder return ErrNoMatch straight away if its
+// initial search matches nothing.
+func (ff *FuzzyFinder) SetExitZero(exitZero bool) *FuzzyFinder {
+	ff.exitZero = exitZero
+	return ff
+}
+
+// FuzzyFindWithInput starts the search at initSearch, returning straight
+// away if that only matches one item. It returns ErrAborted if the user
+// gives up and ErrNoMatch if nothing matched when they pressed Enter.
+func (ff *FuzzyFinder) FuzzyFindWithInput(initSearch string) (int, error) {
+	ids, err := ff.findWithInput(initSearch)
+	if err != nil {
+		return 0, err
+	}
+	return ids[0], nil
+}
+
+func (ff *FuzzyFinder) FuzzyFind() (int, error) {
+	ids, err := ff.find()
+	if err != nil {
+		return 0, err
 	}
-	return ff.FuzzyFind()
+	return ids[0], nil
 }
 
-func (ff *FuzzyFinder) FuzzyFind() int {
+// FuzzyFindMultiWithInput lets the user pick several items, toggling them
+// with Tab, and returns their ids in the order they were selected.
+func (ff *FuzzyFinder) FuzzyFindMultiWithInput(initSearch string) ([]int, error) {
+	ff.multi = true
+	return ff.findWithInput(initSearch)
+}
+
+func (ff *FuzzyFinder) FuzzyFindMulti() ([]int, error) {
+	ff.multi = true
+	return ff.find()
+}
+
+func (ff *FuzzyFinder) findWithInput(initSearch string) ([]int, error) {
+	ff.SetSearchInput(initSearch).SetSelectOne(true)
+	return ff.find()
+}
+
+// find runs the finder until the user picks something or gives up. The
+// terminal is restored however it ends, panics included.
+func (ff *FuzzyFinder) find() ([]int, error) {
+	defer ff.Renderer.Screen.Fini()
+	if ff.stream == nil && len(ff.SearchList) == 0 {
+		return nil, ErrNoMatch
+	}
 	ff.recalcList()
-	ff.setListContent()
+	ff.restoreCursor()
+	ff.setTextBoxContent()
 	ff.draw()
 	return ff.listen()
 }
@@ -112,61 +249,132 @@ func (ff FuzzyFinder) draw() {
 	ff.Renderer.Draw()
 }
 
-func (ff FuzzyFinder) recalc() {
+func (ff *FuzzyFinder) recalc() {
 	w, h := ff.Renderer.Size()
 	ff.Renderer.Sections[listPos].ResizeSection(listSectionDimensions(w, h))
 	ff.Renderer.Sections[textboxPos].ResizeSection(textSectionDimensions(w, h))
+	if ff.preview.fetch != nil {
+		ff.layoutPreview(w, h)
+	}
+	ff.setListContent()
 }
 
-func (ff FuzzyFinder) listen() int {
+func (ff *FuzzyFinder) listen() ([]int, error) {
+	defer ff.stopFilter()
+	defer ff.stopStream()
+	defer ff.stopPreview()
 	for {
+		if ids, done, err := ff.autoPick(); done {
+			return ids, err
+		}
 		ff.draw()
 		switch ev := ff.Renderer.Screen.PollEvent().(type) {
 		case *tcell.EventResize:
 			ff.recalc()
 			ff.draw()
+		case *tcell.EventInterrupt:
+			switch data := ev.Data().(type) {
+			case *filterPass:
+				ff.applyFilter(data)
+			case previewTick:
+				ff.fetchPreview(data)
+			case previewResult:
+				ff.applyPreview(data)
+			case streamWake:
+				ff.mergeStream()
+			case spinnerTick:
+				ff.tickSpinner()
+			}
+		case *tcell.EventMouse:
+			if ids, done, err := ff.handleMouse(ev); done {
+				return ids, err
+			}
+			ff.recalc()
+		case *tcell.EventPaste:
+			ff.prompt.setPasting(ev.Start())
 		case *tcell.EventKey:
+			ff.touched = true
+			if ff.prompt.pasting {
+				ff.editPrompt(ev)
+				break
+			}
 			switch ev.Key() {
 
 			//Exit keys
 			case tcell.KeyEscape:
-				ff.Renderer.Screen.Fini()
-				os.Exit(0)
+				if ff.back {
+					return nil, ErrBack
+				}
+				return nil, ErrAborted
 			case tcell.KeyCtrlC:
-				ff.Renderer.Screen.Fini()
-				os.Exit(0)
+				return nil, ErrAborted
+			case tcell.KeyBackspace, tcell.KeyBackspace2:
+				if ff.back && len(ff.prompt.text) == 0 {
+					return nil, ErrBack
+				}
+				ff.editPrompt(ev)
 
 			case tcell.KeyCtrlL:
 				ff.Renderer.Screen.Sync()
 
-			//Adding a rune
+			//Category tabs, select all, or typing
 			case tcell.KeyRune:
-				ff.fuzzyInputHandler(ev.Rune())
+				alt, r := ev.Modifiers()&tcell.ModAlt != 0, ev.Rune()
+				switch {
+				case alt && r >= '1' && r <= '9':
+					ff.switchCategory(int(r - '1'))
+				case alt && (r == 'a' || r == 'A'):
+					ff.selectAll()
+				default:
+					ff.editPrompt(ev)
+				}
 
-			//Prompt movements
-			case tcell.KeyRight:
-				ff.moveBottomPromptRight()
-			case tcell.KeyLeft:
-				ff.moveBottomPromptLeft()
+			//List movements
 			case tcell.KeyUp:
-				ff.moveTopPromptUp()
+				if !ff.recallQuery(1) {
+					ff.moveListCursor(1)
+				}
 			case tcell.KeyDown:
-				ff.moveTopPromptDown()
-
-			//Removing runes
-			case tcell.KeyBackspace2:
-				ff.backspaceHandler()
-			case tcell.KeyDelete:
-				ff.deleteHandler()
+				if !ff.recallQuery(-1) {
+					ff.moveListCursor(-1)
+				}
+			case tcell.KeyCtrlP:
+				ff.moveListCursor(1)
+			case tcell.KeyCtrlN:
+				ff.moveListCursor(-1)
+			case tcell.KeyPgUp:
+				ff.moveListCursor(ff.listHeight())
+			case tcell.KeyPgDn:
+				ff.moveListCursor(-ff.listHeight())
+			case tcell.KeyHome:
+				ff.moveListCursor(-len(ff.FilteredList))
+			case tcell.KeyEnd:
+				ff.moveListCursor(len(ff.FilteredList))
+
+			//Preview pane
+			case tcell.KeyCtrlT:
+				ff.togglePreview()
+			case tcell.KeyCtrlO:
+				ff.cyclePreviewLayout()
+
+			//Multi-select, or category tabs when picking one item
+			case tcell.KeyTab:
+				if ff.multi {
+					ff.toggleItem()
+				} else {
+					ff.cycleCategory(1)
+				}
+			case tcell.KeyBacktab:
+				ff.cycleCategory(-1)
 
 			//Selecting an entry
 			case tcell.KeyEnter:
-				ff.Renderer.Screen.Fini()
-				item, err := ff.selectItem()
-				if err != nil {
-					log.Fatal("item was not selected")
+				if ids, done, err := ff.accept(); done {
+					return ids, err
 				}
-				return item
+
+			default:
+				ff.editPrompt(ev)
 			}
 			ff.recalc()
 			ff.draw()
@@ -174,161 +382,194 @@ func (ff FuzzyFinder) listen() int {
 	}
 }
 
-func (ff *FuzzyFinder) selectItem() (int, error) {
-	return ff.FilteredList[ff.Renderer.Sections[listPos].EndY-ff.Renderer.Sections[listPos].Cursor.YLoc-1].Id, nil
-}
-
-func (ff *FuzzyFinder) filterList() {
-	ff.FilteredList = []FuzzyContentItem{}
-	for i := 0; i < len(ff.SearchList); i++ {
-		item := &ff.SearchList[i]
-		valid, _ := regexp.Match(createRegex(ff.SearchInput), []byte(string(item.Content)))
-		if valid {
-			ff.FilteredList = append(ff.FilteredList, *item)
-		}
+// accept picks the item under the cursor, or everything selected. On a
+// group header it opens or closes the group instead, and isn't done.
+func (ff *FuzzyFinder) accept() ([]int, bool, error) {
+	if ff.filtering {
+		ff.stopFilter()
+		ff.recalcList()
 	}
-}
-
-func (ff *FuzzyFinder) recalcList() {
-	ff.filterList()
-	for i := 0; i < len(ff.FilteredList); i++ {
-		item := &ff.FilteredList[i]
-		item.LevenshteinDistance = levenshteinDistance([]rune(item.Content), ff.Renderer.Sections[textboxPos].Content[0])
+	if ff.onGroupHeader() {
+		ff.toggleGroup()
+		return nil, false, nil
 	}
-	ff.FilteredList = quickSort(ff.FilteredList, 0, len(ff.FilteredList)-1)
-	ff.setListContent()
-}
-
-func (ff *FuzzyFinder) setListContent() {
-	r := make([][]rune, len(ff.FilteredList))
-	for i, v := range ff.FilteredList {
-		line := []rune(v.Content)
-		r[i] = line
+	if len(ff.selectedOrder) > 0 {
+		return ff.selectedOrder, true, nil
 	}
-	ff.Renderer.Sections[listPos].Content = r
-}
-
-func (ff *FuzzyFinder) charHandler(char rune, pos int) {
-	ff.SearchInput = insertRune(ff.SearchInput, pos, char)
-	ff.moveBottomPromptRight()
-}
-
-func (ff *FuzzyFinder) setTextBoxContent() {
-	ff.Renderer.Sections[textboxPos].Content[0] = append([]rune{'>', ' '}, ff.SearchInput...)
+	item, err := ff.selectItem()
+	if err != nil {
+		return nil, true, ErrNoMatch
+	}
+	return []int{item}, true, nil
 }
 
-func (ff *FuzzyFinder) moveBottomPromptRight() {
-	ff.Renderer.Sections[textboxPos].MoveCursorRight(1)
+func (ff *FuzzyFinder) selectItem() (int, error) {
+	if ff.cursor >= len(ff.FilteredList) || !ff.FilteredList[ff.cursor].Valid {
+		return 0, ErrNoMatch
+	}
+	return ff.FilteredList[ff.cursor].Id, nil
 }
 
-func (ff *FuzzyFinder) moveBottomPromptLeft() {
-	ff.Renderer.Sections[textboxPos].MoveCursorLeft(1)
+// autoPick settles the initial search without the user once every item has
+// arrived, as asked for with SetSelectOne and SetExitZero. It also ends the
+// finder if its stream failed.
+func (ff *FuzzyFinder) autoPick() ([]int, bool, error) {
+	if ff.stream != nil && ff.stream.err != nil {
+		return nil, true, ff.stream.err
+	}
+	if ff.touched || ff.streaming() || ff.filtering {
+		return nil, false, nil
+	}
+	switch {
+	case ff.selectOne && ff.shown == 1:
+		i := slices.IndexFunc(ff.FilteredList, func(item FilteredItem) bool { return item.Valid })
+		return []int{ff.FilteredList[i].Id}, true, nil
+	case ff.exitZero && ff.shown == 0:
+		return nil, true, ErrNoMatch
+	}
+	return nil, false, nil
 }
 
-func (ff *FuzzyFinder) moveTopPromptUp() {
-	ff.Renderer.Sections[listPos].MoveCursorUp(1)
+func (ff *FuzzyFinder) listHeight() int {
+	list := ff.Renderer.Sections[listPos]
+	return max(list.EndY-list.StartY-1, 0)
 }
 
-func (ff *FuzzyFinder) moveTopPromptDown() {
-	ff.Renderer.Sections[listPos].MoveCursorDown(1)
+// moveListCursor moves the selection by n items towards the end of the
+// list, scrolling the viewport to keep it in view.
+func (ff *FuzzyFinder) moveListCursor(n int) {
+	ff.cursor = min(max(ff.cursor+n, 0), max(len(ff.FilteredList)-1, 0))
+	ff.scrollToCursor()
 }
 
-func (ff *FuzzyFinder) backspaceHandler() {
-	if len(ff.SearchInput) > 0 && ff.Renderer.Sections[textboxPos].Cursor.XLoc > 5 {
-		ff.SearchInput = removeRune(ff.SearchInput, ff.Renderer.Sections[textboxPos].Cursor.XLoc-6)
-		ff.setTextBoxContent()
-		ff.moveBottomPromptLeft()
-		ff.recalcList()
+func (ff *FuzzyFinder) scrollToCursor() {
+	height := ff.listHeight()
+	if ff.cursor < ff.offset {
+		ff.offset = ff.cursor
+	} else if height > 0 && ff.cursor >= ff.offset+height {
+		ff.offset = ff.cursor - height + 1
 	}
+	ff.offset = max(min(ff.offset, len(ff.FilteredList)-height), 0)
 }
 
-func (ff *FuzzyFinder) deleteHandler() {
-	stringLen := len(ff.SearchInput)
-	if stringLen > 0 && ff.Renderer.Sections[textboxPos].Cursor.XLoc < 5+stringLen {
-		ff.SearchInput = removeRune(ff.SearchInput, ff.Renderer.Sections[textboxPos].Cursor.XLoc-5)
-		ff.setTextBoxContent()
-		ff.recalcList()
+func (ff *FuzzyFinder) setSelected(id int, selected bool) {
+	if ff.selected == nil {
+		ff.selected = map[int]bool{}
+	}
+	if ff.selected[id] == selected {
+		return
+	}
+	if selected {
+		ff.selected[id] = true
+		ff.selectedOrder = append(ff.selectedOrder, id)
+	} else {
+		delete(ff.selected, id)
+		ff.selectedOrder = slices.DeleteFunc(ff.selectedOrder, func(i int) bool { return i == id })
 	}
 }
 
-func (ff *FuzzyFinder) fuzzyInputHandler(char rune) {
-	loc := ff.Renderer.Sections[textboxPos].Cursor.XLoc
-	ff.charHandler(char, loc)
-	ff.setTextBoxContent()
-	ff.recalcList()
+func (ff *FuzzyFinder) toggleItem() {
+	if !ff.multi {
+		return
+	}
+	id, err := ff.selectItem()
+	if err != nil {
+		return
+	}
+	ff.setSelected(id, !ff.selected[id])
+	ff.moveListCursor(1)
+	ff.setStatus()
 }
 
-func insertRune(runes []rune, index int, in rune) []rune {
-	if index > len(runes) {
-		return append(runes, in)
+func (ff *FuzzyFinder) selectAll() {
+	if !ff.multi {
+		return
+	}
+	for _, item := range ff.FilteredList {
+		if item.Valid {
+			ff.setSelected(item.Id, true)
+		}
 	}
-	ret := make([]rune, 0)
-	ret = append(ret, runes[:index]...)
-	ret = append(ret, in)
-	return append(ret, runes[index:]...)
+	ff.setStatus()
 }
 
-func removeRune(runes []rune, index int) []rune {
-	ret := make([]rune, 0)
-	ret = append(ret, runes[:index]...)
-	return append(ret, runes[index+1:]...)
+func (ff *FuzzyFinder) recalcList() {
+	ff.generation++
+	ff.filtering = false
+	ff.matched, _ = filterItems(context.Background(), ff.candidates(), ff.prompt.text)
+	ff.filteredQuery = append([]rune{}, ff.prompt.text...)
+	ff.showMatches()
+	ff.cursor, ff.offset = 0, 0
+	ff.setListContent()
 }
 
-func createRegex(runes []rune) string {
-	const interleave = "(.*)"
-	inStr := string(runes)
-	outStr := interleave
-	for _, ch := range inStr {
-		outStr = outStr + string(ch) + interleave
+func (ff *FuzzyFinder) setListContent() {
+	ff.scrollToCursor()
+	list := &ff.Renderer.Sections[listPos]
+	visible := ff.FilteredList[ff.offset:min(len(ff.FilteredList), ff.offset+ff.listHeight())]
+	r := make([][]rune, len(visible))
+	highlights := make([][]int, len(visible))
+	for i, v := range visible {
+		if v.fields != nil {
+			r[i], highlights[i] = ff.columns.format(v)
+		} else {
+			r[i] = v.display
+			if v.highlight {
+				highlights[i] = v.Positions
+			}
+		}
+		if ff.tree.enabled && v.Valid {
+			r[i], highlights[i] = prefixLine([]rune("  "), r[i], highlights[i])
+		}
+		if ff.multi {
+			r[i], highlights[i] = prefixLine(ff.selectionMarker(v), r[i], highlights[i])
+		}
 	}
-	return outStr
+	list.Content = r
+	list.Highlights = highlights
+	list.Cursor.YLoc = list.EndY - 1 - (ff.cursor - ff.offset)
+	list.Scroll = ScrollBar{Total: len(ff.FilteredList), Offset: ff.offset, Visible: ff.listHeight()}
+	ff.setStatus()
+	ff.updatePreview()
 }
 
-func levenshteinDistance(a []rune, b []rune) int {
-
-	r1, r2 := a, b
-	column := make([]int, 1, 64)
-
-	for y := 1; y <= len(r1); y++ {
-		column = append(column, y)
+func (ff *FuzzyFinder) selectionMarker(item FilteredItem) []rune {
+	if item.Valid && ff.selected[item.Id] {
+		return []rune{'*', ' '}
 	}
+	return []rune{' ', ' '}
+}
 
-	for x := 1; x <= len(r2); x++ {
-		column[0] = x
-
-		for y, lastDiag := 1, x-1; y <= len(r1); y++ {
-			oldDiag := column[y]
-			cost := 0
-			if r1[y-1] != r2[x-1] {
-				cost = 1
-			}
-			column[y] = min(column[y]+1, column[y-1]+1, lastDiag+cost)
-			lastDiag = oldDiag
-		}
+// prefixLine puts prefix in front of a list line, shifting its match
+// highlights to suit.
+func prefixLine(prefix, line []rune, highlights []int) ([]rune, []int) {
+	shifted := make([]int, len(highlights))
+	for i, p := range highlights {
+		shifted[i] = p + len(prefix)
 	}
+	return append(prefix, line...), shifted
+}
 
-	return column[len(r1)]
+func (ff *FuzzyFinder) setTextBoxContent() {
+	ff.prompt.render(&ff.Renderer.Sections[textboxPos])
+	ff.setStatus()
 }
 
-func partition(arr []FuzzyContentItem, low, high int) ([]FuzzyContentItem, int) {
-	pivot := arr[high]
-	i := low
-	for j := low; j < high; j++ {
-		if arr[j].LevenshteinDistance < pivot.LevenshteinDistance {
-			arr[i], arr[j] = arr[j], arr[i]
-			i++
-		}
+func (ff *FuzzyFinder) setStatus() {
+	status := fmt.Sprintf("%d/%d", ff.shown, len(ff.SearchList))
+	if ff.streaming() {
+		status = string(spinnerFrames[ff.stream.frame]) + " " + status
+	}
+	if ff.multi {
+		status += fmt.Sprintf(" (%d selected)", len(ff.selectedOrder))
 	}
-	arr[i], arr[high] = arr[high], arr[i]
-	return arr, i
+	ff.Renderer.Sections[textboxPos].Status = []rune(status)
 }
 
-func quickSort(arr []FuzzyContentItem, low, high int) []FuzzyContentItem {
-	if low < high {
-		var p int
-		arr, p = partition(arr, low, high)
-		arr = quickSort(arr, low, p-1)
-		arr = quickSort(arr, p+1, high)
+func (ff *FuzzyFinder) editPrompt(ev *tcell.EventKey) {
+	if ff.prompt.handleKey(ev) {
+		ff.history.pos = -1
+		ff.startFilter()
 	}
-	return arr
+	ff.setTextBoxContent()
 }
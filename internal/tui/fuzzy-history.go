@@ -0,0 +1,38 @@
+package tui
+
+// queryHistory steps back through past searches. pos is the one on the
+// prompt, or -1 when the prompt holds something typed.
+type queryHistory struct {
+	past []string
+	pos  int
+}
+
+// SetHistory lets Up and Down on an empty prompt recall past queries,
+// newest first.
+func (ff *FuzzyFinder) SetHistory(queries []string) *FuzzyFinder {
+	ff.history = queryHistory{past: queries, pos: -1}
+	return ff
+}
+
+// Query returns what was on the prompt when the finder closed.
+func (ff *FuzzyFinder) Query() string {
+	return string(ff.prompt.text)
+}
+
+// recallQuery moves by steps through past queries while the prompt is empty
+// or showing one, returning false when the key should move the list instead.
+func (ff *FuzzyFinder) recallQuery(by int) bool {
+	h := &ff.history
+	if len(h.past) == 0 || (h.pos < 0 && len(ff.prompt.text) > 0) {
+		return false
+	}
+	h.pos = min(max(h.pos+by, -1), len(h.past)-1)
+	query := ""
+	if h.pos >= 0 {
+		query = h.past[h.pos]
+	}
+	ff.prompt.setText(query)
+	ff.setTextBoxContent()
+	ff.startFilter()
+	return true
+}
@@ -0,0 +1,303 @@
+package tui
+
+import (
+	"unicode"
+)
+
+const (
+	scoreMatch        = 16
+	scoreGapStart     = -3
+	scoreGapExtension = -1
+
+	bonusBoundary          = scoreMatch / 2
+	bonusBoundaryWhite     = bonusBoundary + 2
+	bonusBoundaryDelimiter = bonusBoundary + 1
+	bonusCamel123          = bonusBoundary + scoreGapExtension
+	bonusConsecutive       = -(scoreGapStart + scoreGapExtension)
+	bonusFirstCharMultiple = 2
+)
+
+const noScore = -1 << 30
+
+type charClass int
+
+const (
+	classWhite charClass = iota
+	classDelimiter
+	classNonWord
+	classLower
+	classUpper
+	classNumber
+)
+
+type Match struct {
+	Score     int
+	Positions []int
+}
+
+func classOf(r rune) charClass {
+	switch {
+	case unicode.IsSpace(r):
+		return classWhite
+	case r == '_' || r == ':' || r == '/' || r == '-' || r == '.' || r == ',' || r == '|':
+		return classDelimiter
+	case unicode.IsLower(r):
+		return classLower
+	case unicode.IsUpper(r):
+		return classUpper
+	case unicode.IsDigit(r):
+		return classNumber
+	case unicode.IsLetter(r):
+		return classLower
+	}
+	return classNonWord
+}
+
+func bonusFor(prev, curr charClass) int {
+	if curr > classNonWord {
+		switch prev {
+		case classWhite:
+			return bonusBoundaryWhite
+		case classDelimiter:
+			return bonusBoundaryDelimiter
+		case classNonWord:
+			return bonusBoundary
+		}
+	}
+	if prev == classLower && curr == classUpper || prev != classNumber && curr == classNumber {
+		return bonusCamel123
+	}
+	if curr == classNonWord || curr == classDelimiter {
+		return bonusBoundary
+	}
+	if curr == classWhite {
+		return bonusBoundaryWhite
+	}
+	return 0
+}
+
+func isCaseSensitive(pattern []rune) bool {
+	for _, r := range pattern {
+		if unicode.IsUpper(r) {
+			return true
+		}
+	}
+	return false
+}
+
+func normalise(r rune, caseSensitive bool) rune {
+	if caseSensitive {
+		return r
+	}
+	return unicode.ToLower(r)
+}
+
+type pattern struct {
+	runes         []rune
+	caseSensitive bool
+}
+
+func newPattern(query []rune) pattern {
+	p := pattern{caseSensitive: isCaseSensitive(query)}
+	p.runes = make([]rune, len(query))
+	for i, r := range query {
+		p.runes[i] = normalise(r, p.caseSensitive)
+	}
+	return p
+}
+
+// preparedText holds everything about an item that scoring needs and that
+// doesn't change between queries.
+type preparedText struct {
+	runes []rune
+	lower []rune
+	bonus []int
+}
+
+func prepareText(runes []rune) preparedText {
+	t := preparedText{
+		runes: runes,
+		lower: make([]rune, len(runes)),
+		bonus: make([]int, len(runes)),
+	}
+	prev := classWhite
+	for j, r := range runes {
+		t.lower[j] = unicode.ToLower(r)
+		curr := classOf(r)
+		t.bonus[j] = bonusFor(prev, curr)
+		prev = curr
+	}
+	return t
+}
+
+func (t preparedText) casedFor(p pattern) []rune {
+	if p.caseSensitive {
+		return t.runes
+	}
+	return t.lower
+}
+
+// matcher keeps its scoring tables between calls so that filtering a large
+// list doesn't allocate for every item. It is not safe for concurrent use.
+type matcher struct {
+	arena      []int
+	matched    []int
+	chunkBonus []int
+	from       []int
+	best       []int
+	bestAt     []int
+}
+
+func grow(buf []int, size int) []int {
+	if cap(buf) < size {
+		return make([]int, size)
+	}
+	return buf[:size]
+}
+
+const arenaSize = 4096
+
+// positions hands out match position slices from a shared slab rather than
+// allocating one per matched item.
+func (mt *matcher) positions(m int) []int {
+	if len(mt.arena) < m {
+		mt.arena = make([]int, max(arenaSize, m))
+	}
+	ret := mt.arena[:m:m]
+	mt.arena = mt.arena[m:]
+	return ret
+}
+
+// window returns the smallest span of text that can hold the best match:
+// from the first occurrence of the pattern's first rune to the last
+// occurrence of its last rune.
+func window(text, p []rune) (int, int, bool) {
+	start, j := -1, 0
+	for i := 0; i < len(text) && j < len(p); i++ {
+		if text[i] == p[j] {
+			if j == 0 {
+				start = i
+			}
+			j++
+		}
+	}
+	if j < len(p) {
+		return 0, 0, false
+	}
+	for end := len(text) - 1; end >= start; end-- {
+		if text[end] == p[len(p)-1] {
+			return start, end + 1, true
+		}
+	}
+	return 0, 0, false
+}
+
+func (mt *matcher) matchSingle(text []rune, bonus []int, r rune, start, end int) Match {
+	at, score := start, noScore
+	for j := start; j < end; j++ {
+		if text[j] == r && bonus[j] > score {
+			at, score = j, bonus[j]
+		}
+	}
+	positions := mt.positions(1)
+	positions[0] = at
+	return Match{Score: scoreMatch + score*bonusFirstCharMultiple, Positions: positions}
+}
+
+func FuzzyMatch(text, query []rune) (Match, bool) {
+	var mt matcher
+	return mt.match(prepareText(text), newPattern(query))
+}
+
+func (mt *matcher) match(t preparedText, pat pattern) (Match, bool) {
+	if len(pat.runes) == 0 {
+		return Match{}, true
+	}
+	p := pat.runes
+	start, end, ok := window(t.casedFor(pat), p)
+	if !ok {
+		return Match{}, false
+	}
+	if len(p) == 1 {
+		return mt.matchSingle(t.casedFor(pat), t.bonus, p[0], start, end), true
+	}
+
+	text, bonus := t.casedFor(pat)[start:end], t.bonus[start:end]
+	n, m := len(text), len(p)
+
+	// matched[i*n+j] is the best score with pattern[i] matched at text[j],
+	// best[i*n+j] the best score with pattern[i] matched at or before text[j].
+	mt.matched = grow(mt.matched, n*m)
+	mt.chunkBonus = grow(mt.chunkBonus, n*m)
+	mt.from = grow(mt.from, n*m)
+	mt.best = grow(mt.best, n*m)
+	mt.bestAt = grow(mt.bestAt, n*m)
+	matched, chunkBonus, from, best, bestAt := mt.matched, mt.chunkBonus, mt.from, mt.best, mt.bestAt
+
+	gapPenalty := func(k int) int {
+		if bestAt[k] == k%n {
+			return scoreGapStart
+		}
+		return scoreGapExtension
+	}
+
+	for i := 0; i < m; i++ {
+		row, prevRow := i*n, (i-1)*n
+		for j := 0; j < n; j++ {
+			k := row + j
+			matched[k] = noScore
+			if text[j] == p[i] {
+				switch {
+				case i == 0:
+					matched[k] = scoreMatch + bonus[j]*bonusFirstCharMultiple
+					chunkBonus[k] = bonus[j]
+					from[k] = -1
+				case j > 0:
+					diag := prevRow + j - 1
+					if matched[diag] > noScore {
+						b := max(bonus[j], chunkBonus[diag], bonusConsecutive)
+						matched[k] = matched[diag] + scoreMatch + b
+						chunkBonus[k] = chunkBonus[diag]
+						from[k] = j - 1
+					}
+					gapScore, gapFrom := noScore, -1
+					if best[diag] > noScore && bestAt[diag] != j-1 {
+						gapScore, gapFrom = best[diag], bestAt[diag]
+					} else if j > 1 && best[diag-1] > noScore {
+						gapScore, gapFrom = best[diag-1]+gapPenalty(diag-1), bestAt[diag-1]
+					}
+					if gapScore > noScore && gapScore+scoreMatch+bonus[j] > matched[k] {
+						matched[k] = gapScore + scoreMatch + bonus[j]
+						chunkBonus[k] = bonus[j]
+						from[k] = gapFrom
+					}
+				}
+			}
+
+			best[k], bestAt[k] = matched[k], j
+			if j > 0 && best[k-1] > noScore {
+				if carried := best[k-1] + gapPenalty(k-1); carried > best[k] {
+					best[k], bestAt[k] = carried, bestAt[k-1]
+				}
+			}
+		}
+	}
+
+	last, score := -1, noScore
+	lastRow := (m - 1) * n
+	for j := 0; j < n; j++ {
+		if matched[lastRow+j] > score {
+			last, score = j, matched[lastRow+j]
+		}
+	}
+	if last < 0 {
+		return Match{}, false
+	}
+
+	positions := mt.positions(m)
+	for i, j := m-1, last; i >= 0; i-- {
+		positions[i] = start + j
+		j = from[i*n+j]
+	}
+	return Match{Score: score, Positions: positions}, true
+}
@@ -0,0 +1,93 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		text, query string
+		want        bool
+		positions   []int
+	}{
+		{"abc", "", true, nil},
+		{"abc", "ac", true, []int{0, 2}},
+		{"abc", "ca", false, nil},
+		{"ab", "abc", false, nil},
+		{"aws_iam_policy", "p", true, []int{8}},
+		{"aws_iam_policy", "iap", true, []int{4, 5, 8}},
+		{"aws_iam_policy", "policy", true, []int{8, 9, 10, 11, 12, 13}},
+		{"xpolicy_policy", "policy", true, []int{8, 9, 10, 11, 12, 13}},
+		{"data-sources: lb_listener", "lbl", true, []int{14, 15, 17}},
+		{"fooBar", "fb", true, []int{0, 3}},
+		{"Foo", "foo", true, []int{0, 1, 2}},
+		{"foo", "Foo", false, nil},
+		{"FoO", "FoO", true, []int{0, 1, 2}},
+		{"FOO", "FoO", false, nil},
+		{"héllo wörld", "hw", true, []int{0, 6}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text+"/"+tt.query, func(t *testing.T) {
+			m, ok := FuzzyMatch([]rune(tt.text), []rune(tt.query))
+			if ok != tt.want {
+				t.Fatalf("matched %v, want %v", ok, tt.want)
+			}
+			if ok && !reflect.DeepEqual(m.Positions, tt.positions) {
+				t.Errorf("got positions %v, want %v", m.Positions, tt.positions)
+			}
+		})
+	}
+}
+
+func TestFuzzyMatchRanking(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		better, worse string
+	}{
+		{"consecutive over gaps", "abc", "xabcx", "xaxbxcx"},
+		{"short gap over long gap", "ab", "axb", "axxxxb"},
+		{"segment start over middle", "role", "aws_role", "awsxrole"},
+		{"word start over delimiter", "role", "aws role", "aws_role"},
+		{"camel case boundary", "b", "fooBar", "foobar"},
+		{"number boundary", "2", "ec2", "v12"},
+		{"first char on a boundary", "iam", "aws_iam", "awsxiam"},
+		{"smart-case ignores case", "role", "aws_ROLE", "awsxrole"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			better, ok := FuzzyMatch([]rune(tt.better), []rune(tt.query))
+			if !ok {
+				t.Fatalf("%q doesn't match %q", tt.better, tt.query)
+			}
+			worse, ok := FuzzyMatch([]rune(tt.worse), []rune(tt.query))
+			if !ok {
+				t.Fatalf("%q doesn't match %q", tt.worse, tt.query)
+			}
+			if better.Score <= worse.Score {
+				t.Errorf("%q scored %d, not above %q's %d", tt.better, better.Score, tt.worse, worse.Score)
+			}
+		})
+	}
+}
+
+func TestRankOf(t *testing.T) {
+	tests := []struct {
+		name          string
+		better, worse [3]int
+	}{
+		{"higher score first", [3]int{20, 10, 5}, [3]int{10, 5, 1}},
+		{"then shorter text", [3]int{10, 5, 5}, [3]int{10, 10, 1}},
+		{"then original order", [3]int{10, 5, 1}, [3]int{10, 5, 2}},
+		{"negative scores", [3]int{-5, 5, 2}, [3]int{-10, 5, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, w := tt.better, tt.worse
+			if rankOf(b[0], b[1], b[2]) >= rankOf(w[0], w[1], w[2]) {
+				t.Errorf("%v doesn't sort before %v", b, w)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package tui
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// handleMouse moves the cursor to a clicked item and accepts it on a double
+// click. The wheel moves the cursor like Up and Down.
+func (ff *FuzzyFinder) handleMouse(ev *tcell.EventMouse) ([]int, bool, error) {
+	list := ff.Renderer.Sections[listPos]
+	x, y := ev.Position()
+	if !inside(list, x, y) {
+		ff.clicks.pressed = ev.Buttons()&tcell.Button1 != 0
+		return nil, false, nil
+	}
+	if by := wheel(ev); by != 0 {
+		ff.touched = true
+		ff.moveListCursor(-by)
+		return nil, false, nil
+	}
+
+	index := ff.offset + list.EndY - 1 - y
+	clicked, double := ff.clicks.click(ev, index)
+	if !clicked || index >= len(ff.FilteredList) {
+		return nil, false, nil
+	}
+	ff.touched = true
+	ff.cursor = index
+	ff.scrollToCursor()
+	if double {
+		return ff.accept()
+	}
+	return nil, false, nil
+}
@@ -0,0 +1,207 @@
+package tui
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/StateOfDenial/tfpd/internal/docs"
+)
+
+const previewPos = 2
+const previewDebounce = 150 * time.Millisecond
+
+type previewLayout struct {
+	bottom  bool
+	percent int
+}
+
+var previewLayouts = []previewLayout{
+	{percent: 50},
+	{percent: 70},
+	{bottom: true, percent: 50},
+}
+
+type previewTick struct {
+	generation int
+}
+
+type previewResult struct {
+	id   int
+	text string
+}
+
+type finderPreview struct {
+	fetch      func(id int) string
+	markdown   bool
+	hidden     bool
+	layout     int
+	id         int
+	generation int
+	cache      map[int]string
+	pending    map[int]bool
+	// ctx ends with the finder, so a fetch whose result can't be posted
+	// stops retrying.
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// SetPreview shows fetch(id) for the highlighted item next to the list.
+// fetch runs off the UI goroutine once the cursor has settled, and its
+// results are cached for the life of the finder.
+func (ff *FuzzyFinder) SetPreview(fetch func(id int) string) *FuzzyFinder {
+	ctx, cancel := context.WithCancel(context.Background())
+	ff.preview = finderPreview{
+		fetch:   fetch,
+		id:      -1,
+		cache:   map[int]string{},
+		pending: map[int]bool{},
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+	ff.Renderer.AddSection(previewPos, *NewSection())
+	return ff
+}
+
+// SetMarkdownPreview is SetPreview for previews written in markdown, which
+// are rendered as the doc viewer renders them.
+func (ff *FuzzyFinder) SetMarkdownPreview(fetch func(id int) string) *FuzzyFinder {
+	ff.SetPreview(fetch)
+	ff.preview.markdown = true
+	return ff
+}
+
+func (ff *FuzzyFinder) layoutPreview(w, h int) {
+	section := &ff.Renderer.Sections[previewPos]
+	section.Hidden = ff.preview.hidden
+	if ff.preview.hidden {
+		return
+	}
+	sx, sy, ex, ey := listSectionDimensions(w, h)
+	l := previewLayouts[ff.preview.layout]
+	if l.bottom {
+		split := sy + (ey-sy)*l.percent/100
+		section.ResizeSection(sx, sy, ex, split)
+		ff.Renderer.Sections[listPos].ResizeSection(sx, split+1, ex, ey)
+	} else {
+		split := ex - (ex-sx)*l.percent/100
+		ff.Renderer.Sections[listPos].ResizeSection(sx, sy, split, ey)
+		section.ResizeSection(split+1, sy, ex, ey)
+	}
+	ff.setPreviewContent()
+}
+
+func (ff *FuzzyFinder) togglePreview() {
+	if ff.preview.fetch == nil {
+		return
+	}
+	ff.preview.hidden = !ff.preview.hidden
+	ff.preview.id = -1
+}
+
+func (ff *FuzzyFinder) cyclePreviewLayout() {
+	if ff.preview.fetch == nil {
+		return
+	}
+	ff.preview.layout = (ff.preview.layout + 1) % len(previewLayouts)
+}
+
+func (ff *FuzzyFinder) setPreviewContent() {
+	section := &ff.Renderer.Sections[previewPos]
+	text, ok := ff.preview.cache[ff.preview.id]
+	if !ok && ff.preview.id >= 0 {
+		text = "Loading..."
+	}
+
+	height := section.EndY - section.StartY - 1
+	width := section.EndX - section.StartX - 4
+	var content [][]rune
+	var styles [][]tcell.Style
+	if ff.preview.markdown && ok {
+		for _, r := range renderMarkdown(docs.ParseMarkdown(text), width) {
+			if len(content) == height {
+				break
+			}
+			content = append(content, r.text)
+			styles = append(styles, r.styles)
+		}
+	} else {
+		for _, l := range strings.Split(text, "\n") {
+			for _, words := range wrapLine(l, width) {
+				if len(content) == height {
+					break
+				}
+				content = append(content, []rune(strings.Join(words, " ")))
+			}
+		}
+	}
+	// Content is drawn from the bottom up, so pad it out to keep the text
+	// at the top of the pane.
+	for len(content) < height {
+		content = append(content, nil)
+	}
+	styles = append(styles, make([][]tcell.Style, len(content)-len(styles))...)
+	slices.Reverse(content)
+	slices.Reverse(styles)
+	section.Content = content
+	section.Styles = styles
+}
+
+// updatePreview follows the cursor, asking for the new item's preview once
+// the cursor has stayed put for previewDebounce.
+func (ff *FuzzyFinder) updatePreview() {
+	if ff.preview.fetch == nil || ff.preview.hidden {
+		return
+	}
+	id, err := ff.selectItem()
+	if err != nil {
+		id = -1
+	}
+	if id == ff.preview.id {
+		return
+	}
+	ff.preview.id = id
+	ff.preview.generation++
+	if _, ok := ff.preview.cache[id]; !ok && id >= 0 {
+		tick := previewTick{generation: ff.preview.generation}
+		screen := ff.Renderer.Screen
+		time.AfterFunc(previewDebounce, func() {
+			screen.PostEvent(tcell.NewEventInterrupt(tick))
+		})
+	}
+	ff.setPreviewContent()
+}
+
+func (ff *FuzzyFinder) fetchPreview(tick previewTick) {
+	id := ff.preview.id
+	if tick.generation != ff.preview.generation || ff.preview.pending[id] {
+		return
+	}
+	if _, ok := ff.preview.cache[id]; ok {
+		return
+	}
+	ff.preview.pending[id] = true
+	fetch, screen, ctx := ff.preview.fetch, ff.Renderer.Screen, ff.preview.ctx
+	go func() {
+		// Dropping the result would leave id pending, and the pane loading,
+		// for good.
+		postUntilCancelled(ctx, screen, tcell.NewEventInterrupt(previewResult{id: id, text: fetch(id)}))
+	}()
+}
+
+func (ff *FuzzyFinder) stopPreview() {
+	if ff.preview.cancel != nil {
+		ff.preview.cancel()
+	}
+}
+
+func (ff *FuzzyFinder) applyPreview(result previewResult) {
+	ff.preview.cache[result.id] = result.text
+	delete(ff.preview.pending, result.id)
+	if result.id == ff.preview.id {
+		ff.setPreviewContent()
+	}
+}
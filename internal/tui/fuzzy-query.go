@@ -0,0 +1,230 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+)
+
+type termKind int
+
+const (
+	termFuzzy termKind = iota
+	termExact
+	termPrefix
+	termSuffix
+	termEqual
+)
+
+type term struct {
+	kind    termKind
+	inverse bool
+	pat     pattern
+}
+
+// query is a parsed extended search: every group must match, and a group
+// matches when any one of its terms does.
+type query struct {
+	groups [][]term
+}
+
+func parseTerm(token string) (term, bool) {
+	t := term{kind: termFuzzy}
+	if strings.HasPrefix(token, "!") {
+		t.inverse = true
+		t.kind = termExact
+		token = token[1:]
+	}
+	switch {
+	case strings.HasPrefix(token, "'"):
+		t.kind = termExact
+		token = token[1:]
+	case strings.HasPrefix(token, "^") && strings.HasSuffix(token, "$") && len(token) > 1:
+		t.kind = termEqual
+		token = token[1 : len(token)-1]
+	case strings.HasPrefix(token, "^"):
+		t.kind = termPrefix
+		token = token[1:]
+	case strings.HasSuffix(token, "$"):
+		t.kind = termSuffix
+		token = token[:len(token)-1]
+	}
+	// A lone | is OR, so \| searches for one.
+	token = strings.ReplaceAll(token, `\|`, "|")
+	if token == "" {
+		return t, false
+	}
+	t.pat = newPattern([]rune(token))
+	return t, true
+}
+
+func parseQuery(input []rune) query {
+	var q query
+	var group []term
+	orNext := false
+	for _, token := range strings.Fields(string(input)) {
+		if token == "|" {
+			orNext = len(group) > 0
+			continue
+		}
+		t, ok := parseTerm(token)
+		if !ok {
+			continue
+		}
+		if !orNext && len(group) > 0 {
+			q.groups = append(q.groups, group)
+			group = nil
+		}
+		group = append(group, t)
+		orNext = false
+	}
+	if len(group) > 0 {
+		q.groups = append(q.groups, group)
+	}
+	return q
+}
+
+func (q query) empty() bool {
+	return len(q.groups) == 0
+}
+
+func (t term) equal(o term) bool {
+	return t.kind == o.kind && t.inverse == o.inverse && slices.Equal(t.pat.runes, o.pat.runes)
+}
+
+func (t term) implies(o term) bool {
+	if t.kind != o.kind || t.inverse || o.inverse || t.pat.caseSensitive != o.pat.caseSensitive {
+		return false
+	}
+	now, was := string(t.pat.runes), string(o.pat.runes)
+	switch t.kind {
+	case termFuzzy:
+		return isLiteralSubsequence(o.pat.runes, t.pat.runes)
+	case termExact:
+		return strings.Contains(now, was)
+	case termPrefix:
+		return strings.HasPrefix(now, was)
+	case termSuffix:
+		return strings.HasSuffix(now, was)
+	}
+	return now == was
+}
+
+// narrows reports whether everything q matches is also matched by prev, so
+// q only needs to be run over prev's results.
+func (q query) narrows(prev query) bool {
+	if prev.empty() || len(q.groups) < len(prev.groups) {
+		return false
+	}
+	for i, group := range prev.groups {
+		next := q.groups[i]
+		if len(group) == 1 && len(next) == 1 && next[0].implies(group[0]) {
+			continue
+		}
+		if !slices.EqualFunc(group, next, term.equal) {
+			return false
+		}
+	}
+	return true
+}
+
+func indexRunes(text, sub []rune, from int) int {
+	for i := from; i+len(sub) <= len(text); i++ {
+		if slices.Equal(text[i:i+len(sub)], sub) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (mt *matcher) matchRun(t preparedText, start, length int) Match {
+	positions := mt.positions(length)
+	score := 0
+	for i := 0; i < length; i++ {
+		positions[i] = start + i
+		b := t.bonus[start+i]
+		if i == 0 {
+			b *= bonusFirstCharMultiple
+		} else {
+			b = max(b, t.bonus[start], bonusConsecutive)
+		}
+		score += scoreMatch + b
+	}
+	return Match{Score: score, Positions: positions}
+}
+
+func (mt *matcher) matchLiteral(t preparedText, tm term) (Match, bool) {
+	text, p := t.casedFor(tm.pat), tm.pat.runes
+	if len(p) > len(text) {
+		return Match{}, false
+	}
+	switch tm.kind {
+	case termPrefix:
+		if !slices.Equal(text[:len(p)], p) {
+			return Match{}, false
+		}
+		return mt.matchRun(t, 0, len(p)), true
+	case termSuffix:
+		if !slices.Equal(text[len(text)-len(p):], p) {
+			return Match{}, false
+		}
+		return mt.matchRun(t, len(text)-len(p), len(p)), true
+	case termEqual:
+		if !slices.Equal(text, p) {
+			return Match{}, false
+		}
+		return mt.matchRun(t, 0, len(p)), true
+	}
+
+	at := -1
+	for i := indexRunes(text, p, 0); i >= 0; i = indexRunes(text, p, i+1) {
+		if at < 0 || t.bonus[i] > t.bonus[at] {
+			at = i
+		}
+	}
+	if at < 0 {
+		return Match{}, false
+	}
+	return mt.matchRun(t, at, len(p)), true
+}
+
+func (mt *matcher) matchTerm(t preparedText, tm term) (Match, bool) {
+	var m Match
+	var ok bool
+	if tm.kind == termFuzzy {
+		m, ok = mt.match(t, tm.pat)
+	} else {
+		m, ok = mt.matchLiteral(t, tm)
+	}
+	if tm.inverse {
+		return Match{}, !ok
+	}
+	return m, ok
+}
+
+func (mt *matcher) matchGroup(t preparedText, group []term) (Match, bool) {
+	best, matched := Match{}, false
+	for _, tm := range group {
+		if m, ok := mt.matchTerm(t, tm); ok && (!matched || m.Score > best.Score) {
+			best, matched = m, true
+		}
+	}
+	return best, matched
+}
+
+func (mt *matcher) matchQuery(t preparedText, q query) (Match, bool) {
+	if len(q.groups) == 1 {
+		return mt.matchGroup(t, q.groups[0])
+	}
+	var total Match
+	for _, group := range q.groups {
+		best, matched := mt.matchGroup(t, group)
+		if !matched {
+			return Match{}, false
+		}
+		total.Score += best.Score
+		total.Positions = append(total.Positions, best.Positions...)
+	}
+	slices.Sort(total.Positions)
+	total.Positions = slices.Compact(total.Positions)
+	return total, true
+}
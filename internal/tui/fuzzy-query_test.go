@@ -0,0 +1,148 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+)
+
+var kindNames = map[termKind]string{
+	termFuzzy:  "fuzzy",
+	termExact:  "exact",
+	termPrefix: "prefix",
+	termSuffix: "suffix",
+	termEqual:  "equal",
+}
+
+func describeQuery(q query) [][]string {
+	var groups [][]string
+	for _, group := range q.groups {
+		var terms []string
+		for _, t := range group {
+			s := kindNames[t.kind] + ":" + string(t.pat.runes)
+			if t.inverse {
+				s = "!" + s
+			}
+			terms = append(terms, s)
+		}
+		groups = append(groups, terms)
+	}
+	return groups
+}
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		input string
+		want  [][]string
+	}{
+		{"", nil},
+		{"aws iam", [][]string{{"fuzzy:aws"}, {"fuzzy:iam"}}},
+		{"'iam ^aws role$ ^aws_iam_role$", [][]string{{"exact:iam"}, {"prefix:aws"}, {"suffix:role"}, {"equal:aws_iam_role"}}},
+		{"!policy !^aws !'x", [][]string{{"!exact:policy"}, {"!prefix:aws"}, {"!exact:x"}}},
+		{"a | b c", [][]string{{"fuzzy:a", "fuzzy:b"}, {"fuzzy:c"}}},
+		{"Aws", [][]string{{"fuzzy:Aws"}}},
+		{"AWS aws", [][]string{{"fuzzy:AWS"}, {"fuzzy:aws"}}},
+		{`a \| b`, [][]string{{"fuzzy:a"}, {"fuzzy:|"}, {"fuzzy:b"}}},
+		{`foo\|bar 'x\|`, [][]string{{"fuzzy:foo|bar"}, {"exact:x|"}}},
+		{"| a", [][]string{{"fuzzy:a"}}},
+		{"a |", [][]string{{"fuzzy:a"}}},
+		{"a | | b", [][]string{{"fuzzy:a", "fuzzy:b"}}},
+		{"! ' ^ $ ^$ |", nil},
+		{"a ! | b", [][]string{{"fuzzy:a", "fuzzy:b"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got := describeQuery(parseQuery([]rune(tt.input)))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryNarrows(t *testing.T) {
+	tests := []struct {
+		prev, next string
+		want       bool
+	}{
+		{"", "a", false},
+		{"a", "a", true},
+		{"a", "ab", true},
+		{"ac", "abc", true},
+		{"ab", "a", false},
+		{"a", "a b", true},
+		{"a b", "a", false},
+		{"'ab", "'abc", true},
+		{"'ab", "'xb", false},
+		{"^ab", "^abc", true},
+		{"^ab", "^xab", false},
+		{"ab$", "xab$", true},
+		{"ab$", "abx$", false},
+		{"^ab$", "^ab$", true},
+		{"^ab$", "^abc$", false},
+		{"a", "'a", false},
+		{"a", "A", false},
+		{"!a", "!ab", false},
+		{"!a", "!a b", true},
+		{"a | b", "a | b c", true},
+		{"a | b", "a | bc", false},
+		{`\|`, `x\|`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.prev+" -> "+tt.next, func(t *testing.T) {
+			got := parseQuery([]rune(tt.next)).narrows(parseQuery([]rune(tt.prev)))
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchQuery(t *testing.T) {
+	tests := []struct {
+		text, query string
+		want        bool
+		positions   []int
+	}{
+		{"aws_iam_role", "", true, nil},
+		{"aws_iam_role", "iam", true, []int{4, 5, 6}},
+		{"aws_iam_role", "iam role", true, []int{4, 5, 6, 8, 9, 10, 11}},
+		{"aws_iam_role", "iam policy", false, nil},
+		{"aws_iam_role", "'iam", true, []int{4, 5, 6}},
+		{"aws_iam_role", "'imr", false, nil},
+		{"aws_iam_role", "^aws", true, []int{0, 1, 2}},
+		{"data_aws", "^aws", false, nil},
+		{"aws_iam_role", "role$", true, []int{8, 9, 10, 11}},
+		{"aws_iam_roles", "role$", false, nil},
+		{"aws_iam_role", "^aws_iam_role$", true, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}},
+		{"aws_iam_role", "^aws$", false, nil},
+		{"aws_iam_role", "!policy", true, nil},
+		{"aws_iam_policy", "!policy", false, nil},
+		{"aws_iam_role", "!^aws", false, nil},
+		{"data_aws", "!^aws", true, nil},
+		{"aws_iam_role", "iam !policy", true, []int{4, 5, 6}},
+		{"aws_iam_role", "policy | role", true, []int{8, 9, 10, 11}},
+		{"aws_s3_bucket", "policy | role", false, nil},
+		{"aws_iam_role", "s3 | | role", true, []int{8, 9, 10, 11}},
+		{"a|b", `\|`, true, []int{1}},
+		{"ab", `\|`, false, nil},
+		{"a|b", `'a\|b`, true, []int{0, 1, 2}},
+		{"AwsRole", "awsrole", true, []int{0, 1, 2, 3, 4, 5, 6}},
+		{"AwsRole", "AwsR", true, []int{0, 1, 2, 3}},
+		{"awsrole", "Aws", false, nil},
+		{"awsrole", "'Aws", false, nil},
+		{"AwsRole", "!role", false, nil},
+		{"AwsRole", "!rOle", true, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text+"/"+tt.query, func(t *testing.T) {
+			var mt matcher
+			m, ok := mt.matchQuery(prepareText([]rune(tt.text)), parseQuery([]rune(tt.query)))
+			if ok != tt.want {
+				t.Fatalf("matched %v, want %v", ok, tt.want)
+			}
+			if ok && !reflect.DeepEqual(m.Positions, tt.positions) {
+				t.Errorf("got positions %v, want %v", m.Positions, tt.positions)
+			}
+		})
+	}
+}
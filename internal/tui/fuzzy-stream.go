@@ -0,0 +1,201 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+const spinnerInterval = 100 * time.Millisecond
+
+var spinnerFrames = []rune("⠋⠙⠹⠸⠼⠴⠦⠧⠇⠏")
+
+type streamWake struct{}
+
+type spinnerTick struct{}
+
+// itemStream holds items read off the caller's channel until the UI
+// goroutine gets round to merging them.
+type itemStream struct {
+	mu       sync.Mutex
+	pending  []FuzzyContentItem
+	done     bool
+	finished bool
+	err      error
+	frame    int
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+func newFuzzyItem(item Item, id int, match []int) FuzzyContentItem {
+	if item.Fields != nil {
+		return newColumnItem(item, id, match)
+	}
+	c := FuzzyContentItem{
+		Content:  item.Display,
+		Valid:    true,
+		Id:       id,
+		text:     prepareText([]rune(item.Search)),
+		category: item.Category,
+		group:    item.Group,
+		boost:    item.Boost,
+	}
+	c.display, c.highlight = c.text.runes, true
+	if item.Display != item.Search {
+		c.display, c.highlight = []rune(item.Display), false
+	}
+	return c
+}
+
+func newColumnItem(item Item, id int, match []int) FuzzyContentItem {
+	c := FuzzyContentItem{
+		Content:  strings.Join(item.Fields, " "),
+		Valid:    true,
+		Id:       id,
+		category: item.Category,
+		group:    item.Group,
+		boost:    item.Boost,
+	}
+	c.fields = make([][]rune, len(item.Fields))
+	for i, f := range item.Fields {
+		c.fields[i] = []rune(f)
+	}
+	search, starts := columnSearch(c.fields, match)
+	c.fieldStarts = starts
+	c.highlight = item.Search == ""
+	if !c.highlight {
+		search = []rune(item.Search)
+	}
+	c.text = prepareText(search)
+	return c
+}
+
+// StreamFuzzyItems fills the finder from in as items arrive, so it can open
+// before they have all been fetched. Ids count up from 0 in the order items
+// are received, and the caller should close in once there are no more.
+func (ff *FuzzyFinder) StreamFuzzyItems(in <-chan string) *FuzzyFinder {
+	items := make(chan Item)
+	go func() {
+		defer close(items)
+		for s := range in {
+			items <- Item{Display: s, Search: s}
+		}
+	}()
+	return ff.StreamItems(items)
+}
+
+func (ff *FuzzyFinder) StreamItems(in <-chan Item) *FuzzyFinder {
+	st := &itemStream{stop: make(chan struct{})}
+	ff.stream = st
+	screen, match := ff.Renderer.Screen, ff.columns.match
+
+	go func() {
+		id := 0
+		for it := range in {
+			item := newFuzzyItem(it, id, match)
+			id++
+			st.mu.Lock()
+			wake := len(st.pending) == 0
+			st.pending = append(st.pending, item)
+			st.mu.Unlock()
+			if wake {
+				screen.PostEvent(tcell.NewEventInterrupt(streamWake{}))
+			}
+		}
+		st.mu.Lock()
+		st.done = true
+		st.mu.Unlock()
+		screen.PostEvent(tcell.NewEventInterrupt(streamWake{}))
+	}()
+
+	go func() {
+		ticker := time.NewTicker(spinnerInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-st.stop:
+				return
+			case <-ticker.C:
+				screen.PostEvent(tcell.NewEventInterrupt(spinnerTick{}))
+			}
+		}
+	}()
+	return ff
+}
+
+// SetStreamErr has the finder call err once a stream from StreamItems ends.
+// If it reports an error, the finder closes and returns it.
+func (ff *FuzzyFinder) SetStreamErr(err func() error) *FuzzyFinder {
+	ff.streamErr = err
+	return ff
+}
+
+func (ff *FuzzyFinder) streaming() bool {
+	return ff.stream != nil && !ff.stream.finished
+}
+
+func (ff *FuzzyFinder) stopStream() {
+	if ff.stream != nil {
+		ff.stream.stopOnce.Do(func() { close(ff.stream.stop) })
+	}
+}
+
+// mergeStream moves whatever has arrived since the last call into the list.
+// The spinner ticks call it too, in case a wake-up event was dropped.
+func (ff *FuzzyFinder) mergeStream() {
+	st := ff.stream
+	st.mu.Lock()
+	batch, done := st.pending, st.done
+	st.pending = nil
+	st.mu.Unlock()
+
+	if len(batch) > 0 {
+		ff.addItems(batch)
+	}
+	if done && !st.finished {
+		st.finished = true
+		if ff.streamErr != nil {
+			st.err = ff.streamErr()
+		}
+		ff.stopStream()
+		ff.setStatus()
+	}
+}
+
+func (ff *FuzzyFinder) addItems(batch []FuzzyContentItem) {
+	start := len(ff.SearchList)
+	ff.SearchList = append(ff.SearchList, batch...)
+	added := make([]FilteredItem, len(batch))
+	for i := range batch {
+		added[i] = FilteredItem{FuzzyContentItem: &ff.SearchList[start+i]}
+		ff.addCategory(batch[i].category)
+	}
+	ff.allItems = append(ff.allItems, added...)
+	ff.columns.fit(batch)
+
+	if ff.filtering {
+		// The pass in flight never saw these items, so run it again over
+		// everything rather than narrowing.
+		ff.filteredQuery = nil
+		ff.startFilter()
+		return
+	}
+	matched, _ := filterItems(context.Background(), added, ff.filteredQuery)
+	if !ranked(parseQuery(ff.filteredQuery), ff.allItems) {
+		ff.matched = append(ff.matched, matched...)
+	} else {
+		total := len(ff.matched) + len(matched)
+		ff.matched = mergeSorted([][]FilteredItem{ff.matched, matched}, total)
+	}
+	ff.showMatches()
+	ff.setListContent()
+}
+
+func (ff *FuzzyFinder) tickSpinner() {
+	ff.stream.frame = (ff.stream.frame + 1) % len(spinnerFrames)
+	ff.mergeStream()
+	ff.setStatus()
+}
@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const ungrouped = "Other"
+
+// finderTree shows items under a header for their Item.Group. Groups start
+// collapsed, but every group with a match is opened while searching.
+type finderTree struct {
+	enabled  bool
+	expanded map[string]bool
+}
+
+// SetTree lists items under a header per Item.Group, opened and closed with
+// Enter.
+func (ff *FuzzyFinder) SetTree(tree bool) *FuzzyFinder {
+	ff.tree = finderTree{enabled: tree, expanded: map[string]bool{}}
+	return ff
+}
+
+// groupTree arranges items under their group headers. The list is drawn from
+// the bottom up, so each header comes after its children. Groups are sorted
+// by name until there's a query, and then by their best match.
+func (ff *FuzzyFinder) groupTree(items []FilteredItem) []FilteredItem {
+	var order []string
+	groups := map[string][]FilteredItem{}
+	for _, item := range items {
+		if _, ok := groups[item.group]; !ok {
+			order = append(order, item.group)
+		}
+		groups[item.group] = append(groups[item.group], item)
+	}
+	searching := !parseQuery(ff.filteredQuery).empty()
+	if !searching {
+		slices.SortFunc(order, func(a, b string) int {
+			// Items without a group go after every named group.
+			if a == "" || b == "" {
+				return len(b) - len(a)
+			}
+			return strings.Compare(a, b)
+		})
+	}
+
+	tree := make([]FilteredItem, 0, len(items)+len(order))
+	for _, g := range order {
+		open := searching || ff.tree.expanded[g]
+		if open {
+			tree = append(tree, groups[g]...)
+		}
+		tree = append(tree, FilteredItem{FuzzyContentItem: newGroupHeader(g, len(groups[g]), open)})
+	}
+	return tree
+}
+
+func newGroupHeader(group string, children int, open bool) *FuzzyContentItem {
+	arrow, label := '▸', group
+	if open {
+		arrow = '▾'
+	}
+	if label == "" {
+		label = ungrouped
+	}
+	return &FuzzyContentItem{
+		Content: group,
+		Id:      -1,
+		display: []rune(fmt.Sprintf("%c %s (%d)", arrow, label, children)),
+		group:   group,
+	}
+}
+
+// toggleGroup opens or closes the group whose header is under the cursor,
+// keeping the cursor on the header. Groups can't be closed while searching.
+func (ff *FuzzyFinder) toggleGroup() {
+	if !parseQuery(ff.filteredQuery).empty() {
+		return
+	}
+	header := ff.FilteredList[ff.cursor]
+	ff.tree.expanded[header.group] = !ff.tree.expanded[header.group]
+	ff.showMatches()
+	ff.cursor = slices.IndexFunc(ff.FilteredList, func(item FilteredItem) bool {
+		return !item.Valid && item.group == header.group
+	})
+	ff.setListContent()
+}
+
+func (ff *FuzzyFinder) onGroupHeader() bool {
+	return ff.tree.enabled && ff.cursor < len(ff.FilteredList) && !ff.FilteredList[ff.cursor].Valid
+}
@@ -0,0 +1,159 @@
+package tui
+
+import (
+	"slices"
+	"unicode"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// contentIndent is how far in from a section's left edge its content starts.
+const contentIndent = 3
+
+var promptMarker = []rune("> ")
+
+// lineEditor is the text and cursor of a one-line prompt, edited with
+// readline's emacs keys. pos counts runes, not cells.
+type lineEditor struct {
+	text    []rune
+	pos     int
+	killed  []rune
+	pasting bool
+}
+
+func (e *lineEditor) setText(text string) {
+	e.text = []rune(text)
+	e.pos = len(e.text)
+}
+
+// setPasting marks the start or end of a bracketed paste. Keys in between
+// are inserted as text, so a pasted newline can't submit the prompt.
+func (e *lineEditor) setPasting(pasting bool) {
+	e.pasting = pasting
+}
+
+// handleKey applies ev to the line, reporting whether the text changed.
+// Keys it doesn't know are ignored.
+func (e *lineEditor) handleKey(ev *tcell.EventKey) bool {
+	if e.pasting {
+		switch ev.Key() {
+		case tcell.KeyRune:
+			return e.insert(ev.Rune())
+		case tcell.KeyEnter, tcell.KeyTab, tcell.KeyCtrlJ:
+			return e.insert(' ')
+		}
+		return false
+	}
+
+	if ev.Key() == tcell.KeyRune && ev.Modifiers()&tcell.ModAlt != 0 {
+		switch ev.Rune() {
+		case 'b':
+			e.pos = e.wordStart()
+		case 'f':
+			e.pos = e.wordEnd()
+		case 'd':
+			return e.kill(e.pos, e.wordEnd())
+		}
+		return false
+	}
+
+	switch ev.Key() {
+	case tcell.KeyRune:
+		return e.insert(ev.Rune())
+	case tcell.KeyLeft, tcell.KeyCtrlB:
+		e.pos = max(e.pos-1, 0)
+	case tcell.KeyRight, tcell.KeyCtrlF:
+		e.pos = min(e.pos+1, len(e.text))
+	case tcell.KeyCtrlA:
+		e.pos = 0
+	case tcell.KeyCtrlE:
+		e.pos = len(e.text)
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if ev.Modifiers()&tcell.ModAlt != 0 {
+			return e.kill(e.wordStart(), e.pos)
+		}
+		return e.remove(e.pos-1, e.pos)
+	case tcell.KeyDelete, tcell.KeyCtrlD:
+		return e.remove(e.pos, e.pos+1)
+	case tcell.KeyCtrlW:
+		start := e.pos
+		for start > 0 && unicode.IsSpace(e.text[start-1]) {
+			start--
+		}
+		for start > 0 && !unicode.IsSpace(e.text[start-1]) {
+			start--
+		}
+		return e.kill(start, e.pos)
+	case tcell.KeyCtrlU:
+		return e.kill(0, e.pos)
+	case tcell.KeyCtrlK:
+		return e.kill(e.pos, len(e.text))
+	case tcell.KeyCtrlY:
+		if len(e.killed) == 0 {
+			return false
+		}
+		e.text = slices.Insert(e.text, e.pos, e.killed...)
+		e.pos += len(e.killed)
+		return true
+	}
+	return false
+}
+
+func (e *lineEditor) insert(r rune) bool {
+	e.text = slices.Insert(e.text, e.pos, r)
+	e.pos++
+	return true
+}
+
+func (e *lineEditor) remove(start, end int) bool {
+	if start < 0 || end > len(e.text) || start >= end {
+		return false
+	}
+	e.text = slices.Delete(e.text, start, end)
+	e.pos = start
+	return true
+}
+
+// kill removes text like remove, keeping it for Ctrl-Y.
+func (e *lineEditor) kill(start, end int) bool {
+	if start >= end {
+		return false
+	}
+	e.killed = slices.Clone(e.text[start:end])
+	return e.remove(start, end)
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func (e *lineEditor) wordStart() int {
+	i := e.pos
+	for i > 0 && !isWordRune(e.text[i-1]) {
+		i--
+	}
+	for i > 0 && isWordRune(e.text[i-1]) {
+		i--
+	}
+	return i
+}
+
+func (e *lineEditor) wordEnd() int {
+	i := e.pos
+	for i < len(e.text) && !isWordRune(e.text[i]) {
+		i++
+	}
+	for i < len(e.text) && isWordRune(e.text[i]) {
+		i++
+	}
+	return i
+}
+
+// render writes the prompt into the first line of s and puts the terminal
+// cursor after the text before pos, measured in cells so wide runes line up.
+func (e *lineEditor) render(s *Section) {
+	s.Content[0] = append(slices.Clone(promptMarker), e.text...)
+	x := s.StartX + contentIndent + runewidth.StringWidth(string(s.Content[0][:len(promptMarker)+e.pos]))
+	s.Cursor.XLoc = min(x, s.Cursor.MaxX)
+}
@@ -0,0 +1,138 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// An edit is one step applied to the editor, reporting whether the text
+// changed.
+type edit func(e *lineEditor) bool
+
+func key(k tcell.Key) edit {
+	return func(e *lineEditor) bool { return e.handleKey(tcell.NewEventKey(k, 0, tcell.ModNone)) }
+}
+
+func alt(r rune) edit {
+	return func(e *lineEditor) bool { return e.handleKey(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModAlt)) }
+}
+
+func altBackspace() edit {
+	return func(e *lineEditor) bool {
+		return e.handleKey(tcell.NewEventKey(tcell.KeyBackspace2, 0, tcell.ModAlt))
+	}
+}
+
+func typed(s string) edit {
+	return func(e *lineEditor) bool {
+		changed := false
+		for _, r := range s {
+			changed = e.handleKey(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone)) || changed
+		}
+		return changed
+	}
+}
+
+// paste sends s the way a terminal does inside a bracketed paste, with
+// newlines and tabs arriving as Ctrl-J and Tab.
+func paste(s string) edit {
+	return func(e *lineEditor) bool {
+		e.setPasting(true)
+		defer e.setPasting(false)
+		changed := false
+		for _, r := range s {
+			ev := tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone)
+			changed = e.handleKey(ev) || changed
+		}
+		return changed
+	}
+}
+
+// pasted sends ev inside a bracketed paste.
+func pasted(ev *tcell.EventKey) edit {
+	return func(e *lineEditor) bool {
+		e.setPasting(true)
+		defer e.setPasting(false)
+		return e.handleKey(ev)
+	}
+}
+
+func TestLineEditorHandleKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		pos      int
+		edits    []edit
+		wantText string
+		wantPos  int
+		changed  bool
+	}{
+		{"insert at cursor", "ac", 1, []edit{typed("b")}, "abc", 2, true},
+		{"insert wide runes", "", 0, []edit{typed("日本")}, "日本", 2, true},
+		{"left and right stop at the ends", "ab", 1, []edit{key(tcell.KeyLeft), key(tcell.KeyLeft), key(tcell.KeyCtrlF), key(tcell.KeyRight), key(tcell.KeyRight)}, "ab", 2, false},
+		{"ctrl-a and ctrl-e", "abc", 1, []edit{key(tcell.KeyCtrlE), typed("d"), key(tcell.KeyCtrlA), typed("x")}, "xabcd", 1, true},
+		{"backspace", "abc", 2, []edit{key(tcell.KeyBackspace2)}, "ac", 1, true},
+		{"backspace at start", "abc", 0, []edit{key(tcell.KeyBackspace)}, "abc", 0, false},
+		{"delete and ctrl-d", "abcd", 1, []edit{key(tcell.KeyDelete), key(tcell.KeyCtrlD)}, "ad", 1, true},
+		{"delete at end", "abc", 3, []edit{key(tcell.KeyDelete)}, "abc", 3, false},
+		{"multibyte rune deletes whole", "aéb", 2, []edit{key(tcell.KeyBackspace2)}, "ab", 1, true},
+		{"alt-b stops at word starts", "aws_iam role", 12, []edit{alt('b')}, "aws_iam role", 8, false},
+		{"alt-b skips delimiters", "aws_iam role", 8, []edit{alt('b'), alt('b')}, "aws_iam role", 0, false},
+		{"alt-f stops at word ends", "aws_iam role", 0, []edit{alt('f'), alt('f')}, "aws_iam role", 7, false},
+		{"alt-f at end", "aws", 3, []edit{alt('f')}, "aws", 3, false},
+		{"alt-d kills next word", "aws_iam role", 3, []edit{alt('d')}, "aws role", 3, true},
+		{"alt-backspace kills previous word", "aws_iam role", 7, []edit{altBackspace()}, "aws_ role", 4, true},
+		{"ctrl-w kills back to whitespace", "aws_iam role  ", 14, []edit{key(tcell.KeyCtrlW)}, "aws_iam ", 8, true},
+		{"ctrl-w at start", "aws", 0, []edit{key(tcell.KeyCtrlW)}, "aws", 0, false},
+		{"ctrl-u kills to start", "aws iam", 4, []edit{key(tcell.KeyCtrlU)}, "iam", 0, true},
+		{"ctrl-k kills to end", "aws iam", 3, []edit{key(tcell.KeyCtrlK)}, "aws", 3, true},
+		{"ctrl-y yanks the last kill", "aws iam", 3, []edit{key(tcell.KeyCtrlK), key(tcell.KeyCtrlA), key(tcell.KeyCtrlY)}, " iamaws", 4, true},
+		{"ctrl-y yanks again", "ab", 2, []edit{key(tcell.KeyCtrlU), key(tcell.KeyCtrlY), key(tcell.KeyCtrlY)}, "abab", 4, true},
+		{"ctrl-y with nothing killed", "ab", 1, []edit{key(tcell.KeyCtrlY)}, "ab", 1, false},
+		{"kill keeps only the latest", "one two", 7, []edit{key(tcell.KeyCtrlW), key(tcell.KeyCtrlW), key(tcell.KeyCtrlY)}, "one ", 4, true},
+		{"paste inserts at cursor", "ab", 1, []edit{paste("xy")}, "axyb", 3, true},
+		{"paste turns newlines and tabs into spaces", "", 0, []edit{paste("a\nb\tc")}, "a b c", 5, true},
+		{"paste ignores editing keys", "x", 1, []edit{pasted(tcell.NewEventKey(tcell.KeyCtrlU, 0, tcell.ModCtrl))}, "x", 1, false},
+		{"paste ignores alt", "", 0, []edit{pasted(tcell.NewEventKey(tcell.KeyRune, 'b', tcell.ModAlt))}, "b", 1, true},
+		{"unknown keys are ignored", "ab", 1, []edit{key(tcell.KeyF1), key(tcell.KeyEsc)}, "ab", 1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &lineEditor{text: []rune(tt.text), pos: tt.pos}
+			changed := false
+			for _, ed := range tt.edits {
+				changed = ed(e) || changed
+			}
+			if string(e.text) != tt.wantText || e.pos != tt.wantPos {
+				t.Errorf("got %q at %d, want %q at %d", string(e.text), e.pos, tt.wantText, tt.wantPos)
+			}
+			if changed != tt.changed {
+				t.Errorf("reported changed %v, want %v", changed, tt.changed)
+			}
+		})
+	}
+}
+
+func TestLineEditorRenderCursor(t *testing.T) {
+	tests := []struct {
+		text  string
+		pos   int
+		wantX int
+	}{
+		{"", 0, 5},
+		{"abc", 1, 6},
+		{"日本語", 2, 9},
+		{"aé日", 3, 9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			e := &lineEditor{text: []rune(tt.text), pos: tt.pos}
+			s := Section{Content: [][]rune{nil}, Cursor: SectionCursor{MaxX: 80}}
+			e.render(&s)
+			if s.Cursor.XLoc != tt.wantX {
+				t.Errorf("cursor at %d, want %d", s.Cursor.XLoc, tt.wantX)
+			}
+		})
+	}
+}
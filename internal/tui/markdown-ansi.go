@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/StateOfDenial/tfpd/internal/docs"
+)
+
+// RenderANSI lays content out width columns wide as the doc viewer does,
+// styled with ANSI escapes for a pager such as less -R.
+func RenderANSI(content string, width int) string {
+	var b strings.Builder
+	for _, r := range renderMarkdown(docs.ParseMarkdown(content), width) {
+		style := tcell.StyleDefault
+		for i, ch := range r.text {
+			if r.styles[i] != style {
+				style = r.styles[i]
+				b.WriteString(sgr(style))
+			}
+			b.WriteRune(ch)
+		}
+		if style != tcell.StyleDefault {
+			b.WriteString(sgr(tcell.StyleDefault))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// sgr is the escape that switches the terminal to style, starting from a
+// reset so nothing of the previous style is left over.
+func sgr(style tcell.Style) string {
+	fg, bg, attrs := style.Decompose()
+	params := []string{"0"}
+	for _, a := range []struct {
+		mask tcell.AttrMask
+		code string
+	}{
+		{tcell.AttrBold, "1"},
+		{tcell.AttrDim, "2"},
+		{tcell.AttrItalic, "3"},
+		{tcell.AttrUnderline, "4"},
+		{tcell.AttrReverse, "7"},
+		{tcell.AttrStrikeThrough, "9"},
+	} {
+		if attrs&a.mask != 0 {
+			params = append(params, a.code)
+		}
+	}
+	params = append(params, colorParams(fg, 30)...)
+	params = append(params, colorParams(bg, 40)...)
+	return "\x1b[" + strings.Join(params, ";") + "m"
+}
+
+// colorParams are the SGR parameters for c as a foreground colour, with base
+// 30, or a background one, with base 40.
+func colorParams(c tcell.Color, base int) []string {
+	switch {
+	case !c.Valid():
+		return nil
+	case c.IsRGB():
+		r, g, b := c.RGB()
+		return []string{strconv.Itoa(base + 8), "2", strconv.Itoa(int(r)), strconv.Itoa(int(g)), strconv.Itoa(int(b))}
+	}
+	n := int(c - tcell.ColorValid)
+	switch {
+	case n < 8:
+		return []string{strconv.Itoa(base + n)}
+	case n < 16:
+		return []string{strconv.Itoa(base + 60 + n - 8)}
+	}
+	return []string{strconv.Itoa(base + 8), "5", strconv.Itoa(n)}
+}
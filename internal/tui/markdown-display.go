@@ -1,23 +1,45 @@
 package tui
 
 import (
-	"os"
+	"errors"
 	"strings"
 
 	"github.com/gdamore/tcell/v2"
+
+	"github.com/StateOfDenial/tfpd/internal/clipboard"
+	"github.com/StateOfDenial/tfpd/internal/docs"
 )
 
-type line struct {
+// row is one screen line of the doc, start runes into line lineNo. Rows of
+// the rendered doc also carry a style and link target for each rune.
+type row struct {
 	lineNo int
-	lines  [][]string
+	start  int
+	text   []rune
+	styles []tcell.Style
+	links  []string
+}
+
+type Tab struct {
+	Title   string
+	Content string
 }
 
 type MDViewer struct {
 	Renderer Renderer
 	Content  string
+	tabs     []Tab
+	active   int
 	lines    []string
-	display  []line
+	nodes    []docs.Node
+	raw      bool
+	rows     []row
+	top      int
+	outline  docs.Outline
+	clicks   clicker
 	width    int
+	back     bool
+	mouse    bool
 }
 
 func mdSectionDimensions(w, h int) (int, int, int, int) {
@@ -37,62 +59,187 @@ func newMDSection(w, h int) *Section {
 	return s
 }
 
-func NewMDViewer(content string) MDViewer {
-	r := NewRenderer()
+func NewMDViewer(content string) (MDViewer, error) {
+	r, err := NewRenderer()
+	if err != nil {
+		return MDViewer{}, err
+	}
 	x, y := r.Size()
 	section := newMDSection(x, y)
 	r.AddSection(0, *section)
+	r.SetMouse(true)
 	return MDViewer{
 		Renderer: r,
 		Content:  content,
 		width:    x - 4,
+		mouse:    true,
+	}, nil
+}
+
+// NewMDViewerTabs opens several docs at once, switching between them with
+// Tab and Shift-Tab.
+func NewMDViewerTabs(tabs []Tab) (MDViewer, error) {
+	m, err := NewMDViewer(tabs[0].Content)
+	if err != nil {
+		return MDViewer{}, err
 	}
+	m.tabs = tabs
+	m.setTitle()
+	return m, nil
+}
+
+// SetBack makes Esc and Backspace return ErrBack, to step back to whatever
+// opened the viewer. q and Ctrl-C still close it.
+func (m *MDViewer) SetBack(back bool) *MDViewer {
+	m.back = back
+	return m
 }
 
-func (m *MDViewer) Display() {
+// SetMouse turns clicking and scrolling in the viewer, and the finders and
+// tables it opens, on or off. It's on unless turned off.
+func (m *MDViewer) SetMouse(enabled bool) *MDViewer {
+	m.mouse = enabled
+	m.Renderer.SetMouse(enabled)
+	return m
+}
+
+func (m *MDViewer) setTitle() {
+	var title []string
+	for i, t := range m.tabs {
+		if i == m.active {
+			title = append(title, "["+t.Title+"]")
+		} else {
+			title = append(title, t.Title)
+		}
+	}
+	m.Renderer.Sections[0].Title = []rune(" " + strings.Join(title, " | ") + " ")
+}
+
+func (m *MDViewer) switchTab(by int) {
+	if len(m.tabs) < 2 {
+		return
+	}
+	m.active = (m.active + by + len(m.tabs)) % len(m.tabs)
+	m.Content = m.tabs[m.active].Content
+	m.top = 0
+	m.setTitle()
 	m.calcLines()
 	m.calcDisplay()
-	m.listen()
+}
+
+// Display shows the doc until the user quits. It only returns an error if
+// one of the viewer's actions, such as copying an example, fails.
+func (m *MDViewer) Display() error {
+	defer m.Renderer.Screen.Fini()
+	m.calcLines()
+	m.calcDisplay()
+	return m.listen()
 }
 
 func (m *MDViewer) calcLines() {
 	m.lines = strings.Split(m.Content, "\n")
+	m.nodes = docs.ParseMarkdown(m.Content)
+	m.outline = docs.ParseOutline(m.Content)
 }
 
 func (m *MDViewer) calcDisplay() {
+	m.rows = nil
+	if !m.raw {
+		m.rows = renderMarkdown(m.nodes, m.width)
+		m.scroll(0)
+		return
+	}
 	for i, l := range m.lines {
-		displayLines := line{
-			lineNo: i + 1,
-		}
-		var temp []string
-		var lenCount int
-
-		for _, w := range strings.Split(l, " ") {
-			if lenCount+len(w) >= m.width {
-				displayLines.lines = append(displayLines.lines, temp)
-				temp = []string{w}
-				lenCount = len(w)
-			} else {
-				temp = append(temp, w)
-				lenCount += len(w) + 1
+		start := 0
+		for _, words := range wrapLine(l, m.width) {
+			text := []rune(strings.Join(words, " "))
+			m.rows = append(m.rows, row{lineNo: i, start: start, text: text})
+			if len(words) > 0 {
+				start += len(text) + 1
 			}
 		}
-		// temp = append(temp, "\n") // append new line at the end that was there originally
-		if len(temp) > 0 {
-			displayLines.lines = append(displayLines.lines, temp)
+	}
+	m.scroll(0)
+}
+
+func (m *MDViewer) height() int {
+	s := m.Renderer.Sections[0]
+	return max(s.EndY-s.StartY-1, 0)
+}
+
+// scroll moves the view by n rows down the doc and redraws its content,
+// which the section draws from the bottom up.
+func (m *MDViewer) scroll(n int) {
+	height := m.height()
+	m.top = min(max(m.top+n, 0), max(len(m.rows)-height, 0))
+	visible := m.rows[m.top:min(m.top+height, len(m.rows))]
+	content := make([][]rune, height)
+	styles := make([][]tcell.Style, height)
+	for i, r := range visible {
+		content[height-1-i] = r.text
+		styles[height-1-i] = r.styles
+	}
+	s := &m.Renderer.Sections[0]
+	s.SetContent(content)
+	s.Styles = styles
+	s.Scroll = ScrollBar{Total: len(m.rows), Offset: max(len(m.rows)-m.top-height, 0), Visible: height}
+}
+
+// scrollToLine brings line lineNo of the doc, or the block it's in, to the
+// top of the view.
+func (m *MDViewer) scrollToLine(lineNo int) {
+	at := -1
+	for i, r := range m.rows {
+		if r.lineNo > lineNo {
+			break
+		}
+		if at < 0 || r.lineNo > m.rows[at].lineNo {
+			at = i
 		}
-		m.display = append(m.display, displayLines)
-	}
-	var content [][]rune
-	for i := len(m.display) - 1; i >= 0; i-- {
-		dl := m.display[i]
-		for j := len(dl.lines) - 1; j >= 0; j-- {
-			lineWords := dl.lines[j]
-			joined := strings.Join(lineWords, " ")
-			content = append(content, []rune(joined))
+	}
+	if at >= 0 {
+		m.scroll(at - m.top)
+	}
+}
+
+// toggleRaw switches between the rendered doc and its markdown source,
+// keeping the same part of it in view.
+func (m *MDViewer) toggleRaw() {
+	lineNo := 0
+	if m.top < len(m.rows) {
+		lineNo = m.rows[m.top].lineNo
+	}
+	m.raw = !m.raw
+	m.calcDisplay()
+	m.top = 0
+	m.scrollToLine(lineNo)
+	status := "rendered"
+	if m.raw {
+		status = "markdown source"
+	}
+	m.Renderer.Sections[0].Status = []rune(status)
+}
+
+// wrapLine splits l into runs of words that fit within width.
+func wrapLine(l string, width int) [][]string {
+	var lines [][]string
+	var temp []string
+	var lenCount int
+
+	for _, w := range strings.Split(l, " ") {
+		if lenCount+len(w) >= width {
+			lines = append(lines, temp)
+			temp = []string{w}
+			lenCount = len(w)
+		} else {
+			temp = append(temp, w)
+			lenCount += len(w) + 1
 		}
 	}
-	m.Renderer.Sections[0].SetContent(content)
+	if len(temp) > 0 {
+		lines = append(lines, temp)
+	}
+	return lines
 }
 
 func (m *MDViewer) draw() {
@@ -112,26 +259,53 @@ func (m *MDViewer) resize() {
 	m.draw()
 }
 
-func (m MDViewer) listen() {
+func (m *MDViewer) listen() error {
 	for {
 		m.draw()
 		switch ev := m.Renderer.Screen.PollEvent().(type) {
 		case *tcell.EventResize:
 			m.resize()
+		case *tcell.EventMouse:
+			m.handleMouse(ev)
 		case *tcell.EventKey:
+			m.Renderer.Sections[0].Status = nil
 			switch ev.Key() {
 
 			//Exit keys
-			case tcell.KeyEscape:
-				m.Renderer.Screen.Fini()
-				os.Exit(0)
+			case tcell.KeyEscape, tcell.KeyBackspace, tcell.KeyBackspace2:
+				if m.back {
+					return ErrBack
+				}
+				if ev.Key() == tcell.KeyEscape {
+					return nil
+				}
 			case tcell.KeyCtrlC:
-				m.Renderer.Screen.Fini()
-				os.Exit(0)
+				return nil
 
 			case tcell.KeyCtrlL:
 				m.Renderer.Screen.Sync()
 
+			case tcell.KeyTab:
+				m.switchTab(1)
+			case tcell.KeyBacktab:
+				m.switchTab(-1)
+
+			case tcell.KeyRune:
+				switch ev.Rune() {
+				case 'q':
+					return nil
+				case 'e':
+					if err := m.copyExample(); err != nil {
+						return err
+					}
+				case 't':
+					if err := m.showReference(); err != nil {
+						return err
+					}
+				case 'r':
+					m.toggleRaw()
+				}
+
 			//Prompt movements
 			case tcell.KeyRight:
 				m.movePromptRight()
@@ -141,7 +315,6 @@ func (m MDViewer) listen() {
 				m.movePromptUp()
 			case tcell.KeyDown:
 				m.movePromptDown()
-				m.draw()
 			}
 		}
 	}
@@ -156,9 +329,58 @@ func (m *MDViewer) movePromptLeft() {
 }
 
 func (m *MDViewer) movePromptUp() {
+	if m.Renderer.Sections[0].Cursor.YLoc == m.Renderer.Sections[0].Cursor.MinY {
+		m.scroll(-1)
+	}
 	m.Renderer.Sections[0].MoveCursorUp(1)
 }
 
 func (m *MDViewer) movePromptDown() {
+	if m.Renderer.Sections[0].Cursor.YLoc == m.Renderer.Sections[0].Cursor.MaxY {
+		m.scroll(1)
+	}
 	m.Renderer.Sections[0].MoveCursorDown(1)
 }
+
+func (m *MDViewer) copyExample() error {
+	examples := docs.Examples(m.Content)
+	if len(examples) == 0 {
+		return nil
+	}
+	items := make([]string, len(examples))
+	for i := range examples {
+		items[i] = examples[i].String()
+	}
+
+	m.Renderer.Screen.Suspend()
+	defer m.Renderer.Screen.Resume()
+	ff, err := NewFuzzyFinder()
+	if err != nil {
+		return err
+	}
+	ff.SetMouse(m.mouse).SetFuzzyItems(items)
+	idx, err := ff.FuzzyFindWithInput("")
+	if errors.Is(err, ErrAborted) || errors.Is(err, ErrNoMatch) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return clipboard.Copy(examples[idx].Code)
+}
+
+func (m *MDViewer) showReference() error {
+	ref := docs.ParseReference(m.Content)
+	if len(ref.Fields) == 0 {
+		return nil
+	}
+
+	m.Renderer.Screen.Suspend()
+	defer m.Renderer.Screen.Resume()
+	t, err := NewTable(ref.Table())
+	if err != nil {
+		return err
+	}
+	t.SetMouse(m.mouse).Display()
+	return nil
+}
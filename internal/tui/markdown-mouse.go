@@ -0,0 +1,84 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/StateOfDenial/tfpd/internal/clipboard"
+	"github.com/StateOfDenial/tfpd/internal/docs"
+)
+
+const registryURL = "https://registry.terraform.io"
+const wheelRows = 3
+
+// handleMouse scrolls the doc with the wheel. Clicking a heading brings it
+// to the top, and clicking a link follows it within the doc or copies it.
+func (m *MDViewer) handleMouse(ev *tcell.EventMouse) {
+	if by := wheel(ev); by != 0 {
+		m.scroll(by * wheelRows)
+		return
+	}
+	s := m.Renderer.Sections[0]
+	x, y := ev.Position()
+	i := m.top + y - s.StartY - 1
+	clicked, _ := m.clicks.click(ev, i)
+	if !clicked || !inside(s, x, y) || i >= len(m.rows) {
+		return
+	}
+	r := m.rows[i]
+	if _, ok := m.outline.Headings[r.lineNo]; ok {
+		m.scrollToLine(r.lineNo)
+		return
+	}
+	offset := runeAtCell(r.text, x-s.StartX-contentIndent)
+	if offset < 0 {
+		return
+	}
+	if !m.raw {
+		if link := r.links[offset]; link != "" {
+			m.followLink(link)
+		}
+		return
+	}
+	if link, ok := docs.LinkAt(m.lines[r.lineNo], r.start+offset); ok {
+		m.followLink(link)
+	}
+}
+
+// followLink jumps to anchors in the doc. Other links can't be opened from
+// here, so they're copied to the clipboard instead.
+func (m *MDViewer) followLink(link string) {
+	if anchor, ok := strings.CutPrefix(link, "#"); ok {
+		if lineNo, ok := m.outline.Anchors[anchor]; ok {
+			m.scrollToLine(lineNo)
+		}
+		return
+	}
+	if strings.HasPrefix(link, "/") {
+		link = registryURL + link
+	}
+	status := "copied " + link
+	if err := clipboard.Copy(link); err != nil {
+		status = "could not copy link: " + err.Error()
+	}
+	s := &m.Renderer.Sections[0]
+	s.Status = []rune(runewidth.Truncate(status, s.EndX-s.StartX-4, "…"))
+}
+
+// runeAtCell returns the index of the rune in text drawn cell columns in,
+// or -1 if that's past its end.
+func runeAtCell(text []rune, cell int) int {
+	if cell < 0 {
+		return -1
+	}
+	x := 0
+	for i, r := range text {
+		x += runewidth.RuneWidth(r)
+		if cell < x {
+			return i
+		}
+	}
+	return -1
+}
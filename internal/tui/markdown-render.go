@@ -0,0 +1,321 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/StateOfDenial/tfpd/internal/docs"
+)
+
+var (
+	headingStyle = tcell.StyleDefault.Foreground(tcell.ColorYellow).Bold(true)
+	codeStyle    = tcell.StyleDefault.Foreground(tcell.ColorGreen)
+	linkStyle    = tcell.StyleDefault.Foreground(tcell.ColorBlue).Underline(true)
+	quoteStyle   = tcell.StyleDefault.Foreground(tcell.ColorGray)
+	tableStyle   = tcell.StyleDefault.Foreground(tcell.ColorGray)
+
+	calloutStyles = map[string]tcell.Style{
+		"note":    tcell.StyleDefault.Foreground(tcell.ColorBlue),
+		"warning": tcell.StyleDefault.Foreground(tcell.ColorYellow),
+		"danger":  tcell.StyleDefault.Foreground(tcell.ColorRed),
+	}
+)
+
+// bullets mark list items, changing with how deeply they're nested.
+var bullets = []string{"•", "◦", "▪"}
+
+// cell is a rune of the rendered doc, with its style and where it links to.
+type cell struct {
+	r     rune
+	style tcell.Style
+	link  string
+}
+
+// renderMarkdown lays a parsed doc out in styled rows width cells wide.
+// Each row keeps the source line its block started on.
+func renderMarkdown(nodes []docs.Node, width int) []row {
+	var rows []row
+	var prev *docs.Node
+	for i, n := range nodes {
+		if n.Kind == docs.Paragraph && strings.TrimSpace(spansText(n.Spans)) == "" {
+			continue
+		}
+		rendered := renderNode(n, width)
+		if len(rendered) == 0 {
+			continue
+		}
+		if prev != nil && !(n.Kind == docs.ListItem && prev.Kind == docs.ListItem) {
+			// Blocks in the same quote stay joined by its bar, but callouts
+			// stand alone.
+			quote := min(n.Quote, prev.Quote)
+			if n.Callout != "" || prev.Callout != "" {
+				quote = 0
+			}
+			rows = append(rows, newRow(rows[len(rows)-1].lineNo, quoteBar(docs.Node{Quote: quote})))
+		}
+		rows = append(rows, rendered...)
+		prev = &nodes[i]
+	}
+	return rows
+}
+
+func renderNode(n docs.Node, width int) []row {
+	bar := quoteBar(n)
+	indent := append(bar, plainCells(strings.Repeat("  ", n.Level), tcell.StyleDefault)...)
+	switch n.Kind {
+	case docs.Heading:
+		style := headingStyle
+		if n.Level == 1 {
+			style = style.Underline(true)
+		} else if n.Level > 2 {
+			style = tcell.StyleDefault.Bold(true)
+		}
+		return wrapCells(n.Line, bar, bar, spanCells(n.Spans, style), width)
+	case docs.ListItem:
+		marker := listMarker(n)
+		first := append(indent, plainCells(marker+" ", quoteStyle)...)
+		rest := append(append([]cell{}, indent...), plainCells(strings.Repeat(" ", runewidth.StringWidth(marker)+1), tcell.StyleDefault)...)
+		return wrapCells(n.Line, first, rest, spanCells(n.Spans, tcell.StyleDefault), width)
+	case docs.CodeBlock:
+		var rows []row
+		prefix := append(indent, plainCells("  ", tcell.StyleDefault)...)
+		for i, l := range n.Lines {
+			code := plainCells(strings.ReplaceAll(l, "\t", "    "), codeStyle)
+			rows = append(rows, chopCells(n.Line+1+i, prefix, code, width)...)
+		}
+		return rows
+	case docs.Rule:
+		rule := strings.Repeat("─", max(width-cellsWidth(indent), 1))
+		return []row{newRow(n.Line, append(indent, plainCells(rule, quoteStyle)...))}
+	case docs.Table:
+		return renderTable(n, indent, width)
+	case docs.FrontMatter:
+		var rows []row
+		for i, l := range n.Lines {
+			rows = append(rows, chopCells(n.Line+1+i, bar, plainCells(l, quoteStyle), width)...)
+		}
+		return rows
+	default:
+		return wrapCells(n.Line, indent, indent, spanCells(n.Spans, tcell.StyleDefault), width)
+	}
+}
+
+// quoteBar draws a bar for each blockquote n is in, coloured for callouts.
+func quoteBar(n docs.Node) []cell {
+	style, ok := calloutStyles[n.Callout]
+	if !ok {
+		style = quoteStyle
+	}
+	return plainCells(strings.Repeat("│ ", n.Quote), style)
+}
+
+func listMarker(n docs.Node) string {
+	switch n.Marker {
+	case "-", "*", "+":
+		return bullets[n.Level%len(bullets)]
+	}
+	return n.Marker
+}
+
+// renderTable lines a table's columns up if they fit, or else lists each
+// row's cells wrapped, split by bars.
+func renderTable(n docs.Node, indent []cell, width int) []row {
+	rows := make([][][]cell, len(n.Rows))
+	var widths []int
+	for i, r := range n.Rows {
+		style := tcell.StyleDefault
+		if i == 0 {
+			style = style.Bold(true)
+		}
+		for j, c := range r {
+			cells := spanCells(c, style)
+			rows[i] = append(rows[i], cells)
+			if j == len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[j] = max(widths[j], cellsWidth(cells))
+		}
+	}
+	total := cellsWidth(indent) + 3*max(len(widths)-1, 0)
+	for _, w := range widths {
+		total += w
+	}
+
+	sep := plainCells(" │ ", tableStyle)
+	var out []row
+	for i, r := range rows {
+		lineNo := n.Line + i
+		if i > 0 {
+			lineNo++
+		}
+		line := append([]cell{}, indent...)
+		for j, c := range r {
+			if j > 0 {
+				line = append(line, sep...)
+			}
+			line = append(line, c...)
+			if total <= width && j < len(r)-1 {
+				line = append(line, plainCells(strings.Repeat(" ", widths[j]-cellsWidth(c)), tcell.StyleDefault)...)
+			}
+		}
+		if total > width {
+			out = append(out, wrapCells(lineNo, indent, indent, line[len(indent):], width)...)
+			continue
+		}
+		out = append(out, newRow(lineNo, line))
+		if i == 0 {
+			divider := make([]string, len(widths))
+			for j, w := range widths {
+				divider[j] = strings.Repeat("─", w)
+			}
+			out = append(out, newRow(lineNo, append(append([]cell{}, indent...), plainCells(strings.Join(divider, "─┼─"), tableStyle)...)))
+		}
+	}
+	return out
+}
+
+func spansText(spans []docs.Span) string {
+	var sb strings.Builder
+	for _, s := range spans {
+		sb.WriteString(s.Text)
+	}
+	return sb.String()
+}
+
+// spanCells styles each span's runes on top of base.
+func spanCells(spans []docs.Span, base tcell.Style) []cell {
+	var cells []cell
+	for _, s := range spans {
+		style := base
+		if s.Style&docs.Code != 0 {
+			fg, _, _ := codeStyle.Decompose()
+			style = style.Foreground(fg)
+		}
+		if s.Style&docs.Link != 0 {
+			fg, _, _ := linkStyle.Decompose()
+			style = style.Foreground(fg).Underline(true)
+		}
+		if s.Style&docs.Strong != 0 {
+			style = style.Bold(true)
+		}
+		if s.Style&docs.Emphasis != 0 {
+			style = style.Italic(true)
+		}
+		if s.Style&docs.Strike != 0 {
+			style = style.StrikeThrough(true)
+		}
+		for _, r := range s.Text {
+			cells = append(cells, cell{r: r, style: style, link: s.Link})
+		}
+	}
+	return cells
+}
+
+func plainCells(s string, style tcell.Style) []cell {
+	cells := make([]cell, 0, len(s))
+	for _, r := range s {
+		cells = append(cells, cell{r: r, style: style})
+	}
+	return cells
+}
+
+func cellsWidth(cells []cell) int {
+	w := 0
+	for _, c := range cells {
+		w += runewidth.RuneWidth(c.r)
+	}
+	return w
+}
+
+func newRow(lineNo int, cells []cell) row {
+	r := row{lineNo: lineNo, text: make([]rune, len(cells)), styles: make([]tcell.Style, len(cells)), links: make([]string, len(cells))}
+	for i, c := range cells {
+		r.text[i], r.styles[i], r.links[i] = c.r, c.style, c.link
+	}
+	return r
+}
+
+// wrapCells fills rows with text's words, starting the first row with first
+// and the rest with rest. Runs of spaces collapse to one, which keeps the
+// style around it when both sides share it, so links stay underlined.
+func wrapCells(lineNo int, first, rest, text []cell, width int) []row {
+	var rows []row
+	line := append([]cell{}, first...)
+	used, empty := cellsWidth(first), true
+	for _, word := range words(text) {
+		w := cellsWidth(word)
+		if !empty && used+1+w > width {
+			rows = append(rows, newRow(lineNo, line))
+			line = append([]cell{}, rest...)
+			used, empty = cellsWidth(rest), true
+		}
+		if !empty {
+			space := cell{r: ' '}
+			if last := line[len(line)-1]; last.style == word[0].style && last.link == word[0].link {
+				space.style, space.link = last.style, last.link
+			}
+			line = append(line, space)
+			used++
+		}
+		for used+w > width && empty && len(word) > 1 {
+			// A word too long for a row of its own is split across rows.
+			n := fitCells(word, width-used)
+			rows = append(rows, newRow(lineNo, append(line, word[:n]...)))
+			line = append([]cell{}, rest...)
+			used = cellsWidth(rest)
+			word = word[n:]
+			w = cellsWidth(word)
+		}
+		line = append(line, word...)
+		used += w
+		empty = false
+	}
+	return append(rows, newRow(lineNo, line))
+}
+
+// chopCells splits text across rows at width regardless of words, for code
+// and anything else whose spacing matters.
+func chopCells(lineNo int, prefix, text []cell, width int) []row {
+	var rows []row
+	for {
+		n := fitCells(text, width-cellsWidth(prefix))
+		rows = append(rows, newRow(lineNo, append(append([]cell{}, prefix...), text[:n]...)))
+		text = text[n:]
+		if len(text) == 0 {
+			return rows
+		}
+	}
+}
+
+// fitCells counts how many of cells fit in width, always at least one.
+func fitCells(cells []cell, width int) int {
+	w := 0
+	for i, c := range cells {
+		w += runewidth.RuneWidth(c.r)
+		if w > width {
+			return max(i, 1)
+		}
+	}
+	return len(cells)
+}
+
+func words(text []cell) [][]cell {
+	var words [][]cell
+	start := -1
+	for i, c := range text {
+		space := c.r == ' ' || c.r == '\t' || c.r == '\n'
+		switch {
+		case space && start >= 0:
+			words = append(words, text[start:i])
+			start = -1
+		case !space && start < 0:
+			start = i
+		}
+	}
+	if start >= 0 {
+		words = append(words, text[start:])
+	}
+	return words
+}
@@ -0,0 +1,157 @@
+package tui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/StateOfDenial/tfpd/internal/docs"
+)
+
+func rowTexts(rows []row) []string {
+	var texts []string
+	for _, r := range rows {
+		texts = append(texts, strings.TrimRight(string(r.text), " "))
+	}
+	return texts
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		width   int
+		want    []string
+	}{
+		{
+			name:    "empty front matter first",
+			content: "---\n---\n# Title",
+			width:   40,
+			want:    []string{"Title"},
+		},
+		{
+			name:    "empty code block first",
+			content: "```\n```\ntext",
+			width:   40,
+			want:    []string{"text"},
+		},
+		{
+			name:    "only empty blocks",
+			content: "```\n```\n<a id=\"x\"></a>",
+			width:   40,
+			want:    nil,
+		},
+		{
+			name:    "paragraph wraps",
+			content: "one two three four",
+			width:   9,
+			want:    []string{"one two", "three", "four"},
+		},
+		{
+			name:    "long word splits",
+			content: "abcdefghij",
+			width:   4,
+			want:    []string{"abcd", "efgh", "ij"},
+		},
+		{
+			name:    "nested lists",
+			content: "* a\n  * b\n    * c\n1. d\n\n   in d",
+			width:   40,
+			want:    []string{"• a", "  ◦ b", "    ▪ c", "1. d", "", "  in d"},
+		},
+		{
+			name:    "list item wraps under its text",
+			content: "- one two three",
+			width:   9,
+			want:    []string{"• one two", "  three"},
+		},
+		{
+			name:    "quotes",
+			content: "> outer\n>\n> > inner",
+			width:   40,
+			want:    []string{"│ outer", "│", "│ │ inner"},
+		},
+		{
+			name:    "callouts stand apart",
+			content: "~> careful\n\n-> note",
+			width:   40,
+			want:    []string{"│ careful", "", "│ note"},
+		},
+		{
+			name:    "table fits",
+			content: "| Name | Value |\n|---|---|\n| a | `b` |",
+			width:   40,
+			want:    []string{"Name │ Value", "─────┼──────", "a    │ b"},
+		},
+		{
+			name:    "table too wide wraps",
+			content: "| Name | Value |\n|---|---|\n| a | b |",
+			width:   8,
+			want:    []string{"Name │", "Value", "a │ b"},
+		},
+		{
+			name:    "code keeps spacing",
+			content: "```\na  =  1\n```",
+			width:   40,
+			want:    []string{"  a  =  1"},
+		},
+		{
+			name:    "rule",
+			content: "a\n\n***",
+			width:   5,
+			want:    []string{"a", "", "─────"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rowTexts(renderMarkdown(docs.ParseMarkdown(tt.content), tt.width))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderMarkdownStyles(t *testing.T) {
+	rows := renderMarkdown(docs.ParseMarkdown("**b** `c` [l](#x)"), 40)
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows, want 1", len(rows))
+	}
+	r := rows[0]
+	if string(r.text) != "b c l" {
+		t.Fatalf("got %q", string(r.text))
+	}
+	if _, _, attrs := r.styles[0].Decompose(); attrs&tcell.AttrBold == 0 {
+		t.Error("strong text isn't bold")
+	}
+	if fg, _, _ := r.styles[2].Decompose(); fg != tcell.ColorGreen {
+		t.Errorf("inline code is %v, want green", fg)
+	}
+	if r.links[4] != "#x" || r.links[0] != "" {
+		t.Errorf("got links %q, want only the last rune linked", r.links)
+	}
+	if _, _, attrs := r.styles[4].Decompose(); attrs&tcell.AttrUnderline == 0 {
+		t.Error("link isn't underlined")
+	}
+}
+
+func TestRenderANSI(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"plain text", "text", "text\n"},
+		{"heading", "## Usage", "\x1b[0;1;93mUsage\x1b[0m\n"},
+		{"inline code", "use `id`", "use \x1b[0;32mid\x1b[0m\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenderANSI(tt.content, 40); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package tui
+
+import (
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+const doubleClickInterval = 400 * time.Millisecond
+
+// clicker turns button presses into clicks, ignoring the release and
+// noticing when the same spot is clicked twice in quick succession.
+type clicker struct {
+	pressed bool
+	target  int
+	at      time.Time
+}
+
+// click reports whether ev is a fresh left click, and whether it's the
+// second click on target in a row.
+func (c *clicker) click(ev *tcell.EventMouse, target int) (clicked, double bool) {
+	if ev.Buttons()&tcell.Button1 == 0 {
+		c.pressed = false
+		return false, false
+	}
+	if c.pressed {
+		return false, false
+	}
+	c.pressed = true
+	double = target == c.target && ev.When().Sub(c.at) < doubleClickInterval
+	c.target, c.at = target, ev.When()
+	if double {
+		c.at = time.Time{}
+	}
+	return true, double
+}
+
+func wheel(ev *tcell.EventMouse) int {
+	switch {
+	case ev.Buttons()&tcell.WheelUp != 0:
+		return -1
+	case ev.Buttons()&tcell.WheelDown != 0:
+		return 1
+	}
+	return 0
+}
+
+func inside(s Section, x, y int) bool {
+	return x > s.StartX && x < s.EndX && y > s.StartY && y < s.EndY
+}
@@ -0,0 +1,44 @@
+package tui
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// Prompt asks question, starting the reply at answer, and returns the line
+// typed, or ErrAborted if the user gives up with Esc or Ctrl-C.
+func Prompt(question, answer string) (string, error) {
+	r, err := NewRenderer()
+	if err != nil {
+		return "", err
+	}
+	defer r.Screen.Fini()
+	r.Screen.EnablePaste()
+	w, h := r.Size()
+	textbox := newTextSection(w, h)
+	textbox.Content = append(textbox.Content, promptMarker)
+	textbox.Title = []rune(" " + question + " ")
+	r.AddSection(textboxPos, *textbox)
+
+	var line lineEditor
+	line.setText(answer)
+	for {
+		line.render(&r.Sections[textboxPos])
+		r.Draw()
+		switch ev := r.Screen.PollEvent().(type) {
+		case *tcell.EventResize:
+			r.Sections[textboxPos].ResizeSection(textSectionDimensions(r.Size()))
+		case *tcell.EventPaste:
+			line.setPasting(ev.Start())
+		case *tcell.EventKey:
+			if !line.pasting {
+				switch ev.Key() {
+				case tcell.KeyEscape, tcell.KeyCtrlC:
+					return "", ErrAborted
+				case tcell.KeyEnter:
+					return string(line.text), nil
+				}
+			}
+			line.handleKey(ev)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package tui
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+const tableTextboxPos = 0
+const tableBodyPos = 1
+const maxColumnWidth = 40
+
+type Table struct {
+	Renderer Renderer
+	Headers  []string
+	Rows     [][]string
+	filtered [][]string
+	prompt   lineEditor
+	sortCol  int
+	sortDesc bool
+	offset   int
+}
+
+func NewTable(headers []string, rows [][]string) (Table, error) {
+	r, err := NewRenderer()
+	if err != nil {
+		return Table{}, err
+	}
+	x, y := r.Size()
+	textbox := newTextSection(x, y)
+	textbox.Content = append(textbox.Content, promptMarker)
+	body := newListSection(x, y)
+	body.Cursor = SectionCursor{}
+	r.AddSection(tableTextboxPos, *textbox).
+		AddSection(tableBodyPos, *body)
+	r.Screen.EnablePaste()
+	r.SetMouse(true)
+	return Table{
+		Renderer: r,
+		Headers:  headers,
+		Rows:     rows,
+		sortCol:  -1,
+	}, nil
+}
+
+// SetMouse turns scrolling the table with the wheel on or off. It's on
+// unless turned off.
+func (t *Table) SetMouse(enabled bool) *Table {
+	t.Renderer.SetMouse(enabled)
+	return t
+}
+
+func (t *Table) Display() {
+	defer t.Renderer.Screen.Fini()
+	t.recalcRows()
+	t.listen()
+}
+
+func (t *Table) recalcRows() {
+	terms := strings.Fields(strings.ToLower(string(t.prompt.text)))
+	t.filtered = t.filtered[:0]
+	for _, row := range t.Rows {
+		if rowMatches(row, terms) {
+			t.filtered = append(t.filtered, row)
+		}
+	}
+	if t.sortCol >= 0 {
+		sort.SliceStable(t.filtered, func(i, j int) bool {
+			a, b := t.filtered[i][t.sortCol], t.filtered[j][t.sortCol]
+			if t.sortDesc {
+				return a > b
+			}
+			return a < b
+		})
+	}
+	t.offset = min(t.offset, max(len(t.filtered)-1, 0))
+	t.setContent()
+}
+
+func rowMatches(row []string, terms []string) bool {
+	joined := strings.ToLower(strings.Join(row, " "))
+	for _, term := range terms {
+		if !strings.Contains(joined, term) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *Table) columnWidths(total int) []int {
+	widths := make([]int, len(t.Headers))
+	for i, h := range t.Headers {
+		widths[i] = runewidth.StringWidth(h) + 2
+	}
+	for _, row := range t.Rows {
+		for i, cell := range row {
+			widths[i] = min(max(widths[i], runewidth.StringWidth(cell)), maxColumnWidth)
+		}
+	}
+	used := 0
+	for _, w := range widths[:len(widths)-1] {
+		used += w + 2
+	}
+	widths[len(widths)-1] = max(total-used, 10)
+	return widths
+}
+
+func formatRow(cells []string, widths []int) []rune {
+	var sb strings.Builder
+	for i, cell := range cells {
+		cell = runewidth.Truncate(cell, widths[i], "…")
+		if i < len(cells)-1 {
+			cell = runewidth.FillRight(cell, widths[i]) + "  "
+		}
+		sb.WriteString(cell)
+	}
+	return []rune(sb.String())
+}
+
+func (t *Table) header() []string {
+	headers := make([]string, len(t.Headers))
+	copy(headers, t.Headers)
+	if t.sortCol >= 0 {
+		marker := " ▲"
+		if t.sortDesc {
+			marker = " ▼"
+		}
+		headers[t.sortCol] += marker
+	}
+	return headers
+}
+
+func (t *Table) visibleRows() int {
+	body := t.Renderer.Sections[tableBodyPos]
+	return max(body.EndY-body.StartY-2, 1)
+}
+
+func (t *Table) setContent() {
+	w, _ := t.Renderer.Size()
+	widths := t.columnWidths(w - 6)
+	end := min(t.offset+t.visibleRows(), len(t.filtered))
+	lines := [][]rune{formatRow(t.header(), widths)}
+	for _, row := range t.filtered[t.offset:end] {
+		lines = append(lines, formatRow(row, widths))
+	}
+
+	content := make([][]rune, 0, t.visibleRows()+1)
+	for i := t.visibleRows(); i >= len(lines); i-- {
+		content = append(content, []rune{})
+	}
+	for i := len(lines) - 1; i >= 0; i-- {
+		content = append(content, lines[i])
+	}
+	t.Renderer.Sections[tableBodyPos].SetContent(content)
+	t.prompt.render(&t.Renderer.Sections[tableTextboxPos])
+}
+
+func (t *Table) recalc() {
+	w, h := t.Renderer.Size()
+	t.Renderer.Sections[tableBodyPos].ResizeSection(listSectionDimensions(w, h))
+	t.Renderer.Sections[tableTextboxPos].ResizeSection(textSectionDimensions(w, h))
+	t.setContent()
+}
+
+func (t *Table) scroll(n int) {
+	t.offset = min(max(t.offset+n, 0), max(len(t.filtered)-t.visibleRows(), 0))
+	t.setContent()
+}
+
+func (t *Table) cycleSort(step int) {
+	next := t.sortCol + step
+	if next < -1 {
+		next = len(t.Headers) - 1
+	} else if next >= len(t.Headers) {
+		next = -1
+	}
+	t.sortCol = next
+	t.recalcRows()
+}
+
+func (t *Table) listen() {
+	for {
+		t.Renderer.Draw()
+		switch ev := t.Renderer.Screen.PollEvent().(type) {
+		case *tcell.EventResize:
+			t.recalc()
+		case *tcell.EventPaste:
+			t.prompt.setPasting(ev.Start())
+		case *tcell.EventMouse:
+			if n := wheel(ev); n != 0 {
+				t.scroll(n)
+			}
+		case *tcell.EventKey:
+			if t.prompt.pasting {
+				t.editFilter(ev)
+				break
+			}
+			switch ev.Key() {
+			case tcell.KeyEscape, tcell.KeyCtrlC, tcell.KeyEnter:
+				return
+			case tcell.KeyCtrlL:
+				t.Renderer.Screen.Sync()
+			case tcell.KeyTab:
+				t.cycleSort(1)
+			case tcell.KeyBacktab:
+				t.cycleSort(-1)
+			case tcell.KeyCtrlR:
+				t.sortDesc = !t.sortDesc
+				t.recalcRows()
+			case tcell.KeyUp:
+				t.scroll(-1)
+			case tcell.KeyDown:
+				t.scroll(1)
+			case tcell.KeyPgUp:
+				t.scroll(-t.visibleRows())
+			case tcell.KeyPgDn:
+				t.scroll(t.visibleRows())
+			default:
+				t.editFilter(ev)
+			}
+		}
+	}
+}
+
+func (t *Table) editFilter(ev *tcell.EventKey) {
+	if t.prompt.handleKey(ev) {
+		t.recalcRows()
+	} else {
+		t.setContent()
+	}
+}
@@ -1,9 +1,6 @@
 package tui
 
 import (
-	"fmt"
-	"os"
-
 	"github.com/gdamore/tcell/v2"
 	"github.com/mattn/go-runewidth"
 )
@@ -26,15 +23,30 @@ type SectionCursor struct {
 	MaxY int
 }
 
+// ScrollBar describes a section whose content is a window onto a longer
+// list, counted from the bottom row up.
+type ScrollBar struct {
+	Total   int
+	Offset  int
+	Visible int
+}
+
 type Section struct {
-	StartX      int
-	StartY      int
-	EndX        int
-	EndY        int
-	Content     [][]rune
-	Cursor      SectionCursor
-	TextStyle   tcell.Style
-	BorderStyle tcell.Style
+	StartX         int
+	StartY         int
+	EndX           int
+	EndY           int
+	Title          []rune
+	Status         []rune
+	Content        [][]rune
+	Highlights     [][]int
+	Styles         [][]tcell.Style
+	Scroll         ScrollBar
+	Hidden         bool
+	Cursor         SectionCursor
+	TextStyle      tcell.Style
+	HighlightStyle tcell.Style
+	BorderStyle    tcell.Style
 }
 
 type Renderer struct {
@@ -127,8 +139,30 @@ func (s Section) Boundaries() (bsx int, bsy int, bex int, bey int) {
 
 func (s Section) Draw(screen tcell.Screen) {
 	drawBox(screen, s.StartX, s.StartY, s.EndX, s.EndY, s.BorderStyle)
+	x := s.StartX + 2
+	for _, c := range s.Title {
+		if x >= s.EndX-1 {
+			break
+		}
+		screen.SetContent(x, s.StartY, c, nil, s.BorderStyle)
+		x += runewidth.RuneWidth(c)
+	}
 	for i, line := range s.Content {
-		emitStr(screen, s, s.StartX+3, s.EndY-i-1, string(line))
+		var highlights []int
+		if i < len(s.Highlights) {
+			highlights = s.Highlights[i]
+		}
+		var styles []tcell.Style
+		if i < len(s.Styles) {
+			styles = s.Styles[i]
+		}
+		emitStyledStr(screen, s, s.StartX+contentIndent, s.EndY-i-1, line, highlights, styles)
+	}
+	if s.Scroll.Total > s.Scroll.Visible && s.Scroll.Visible > 0 {
+		drawScrollBar(screen, s)
+	}
+	if len(s.Status) > 0 {
+		emitStr(screen, s, s.EndX-2-runewidth.StringWidth(string(s.Status)), s.EndY-1, string(s.Status))
 	}
 	if s.Cursor != (SectionCursor{}) {
 		if s.Cursor.Type == Typing {
@@ -179,20 +213,28 @@ func (s *Section) MoveCursorLeft(m int) {
 	}
 }
 
-func NewRenderer() Renderer {
-	s, e := tcell.NewScreen()
-	if e != nil {
-		fmt.Fprintf(os.Stderr, "%v\n", e)
-		os.Exit(1)
+// NewRenderer takes over the terminal, failing if there isn't one that can
+// be drawn on.
+func NewRenderer() (Renderer, error) {
+	s, err := tcell.NewScreen()
+	if err != nil {
+		return Renderer{}, err
 	}
-
-	if e := s.Init(); e != nil {
-		fmt.Fprintf(os.Stderr, "%v\n", e)
-		os.Exit(1)
+	if err := s.Init(); err != nil {
+		return Renderer{}, err
 	}
-
 	return Renderer{
 		Screen: s,
+	}, nil
+}
+
+// SetMouse has the screen take over the mouse from the terminal, or hand it
+// back for people who would rather keep their terminal's own text selection.
+func (r *Renderer) SetMouse(enabled bool) {
+	if enabled {
+		r.Screen.EnableMouse(tcell.MouseButtonEvents)
+	} else {
+		r.Screen.DisableMouse()
 	}
 }
 
@@ -214,16 +256,25 @@ func (r Renderer) Size() (w int, h int) {
 
 func (r Renderer) Draw() {
 	for _, s := range r.Sections {
+		if s.Hidden {
+			continue
+		}
 		s.Draw(r.Screen)
 	}
 	r.Screen.Show()
 }
 
 func emitStr(s tcell.Screen, section Section, x, y int, str string) {
+	emitStyledStr(s, section, x, y, []rune(str), nil, nil)
+}
+
+// emitStyledStr draws str in the section's text style, or in styles where
+// given a style per rune, and the highlighted runes in its highlight style.
+func emitStyledStr(s tcell.Screen, section Section, x, y int, str []rune, highlights []int, styles []tcell.Style) {
 	bsx, bsy, bex, bey := section.Boundaries()
 
 	if y < bey && y > bsy {
-		for _, c := range str {
+		for i, c := range str {
 			var comb []rune
 			w := runewidth.RuneWidth(c)
 			if w == 0 {
@@ -231,14 +282,34 @@ func emitStr(s tcell.Screen, section Section, x, y int, str string) {
 				c = ' '
 				w = 1
 			}
+			style := section.TextStyle
+			if i < len(styles) {
+				style = styles[i]
+			}
+			if len(highlights) > 0 && highlights[0] == i {
+				style = section.HighlightStyle
+				highlights = highlights[1:]
+			}
 			if x < bex && x > bsx {
-				s.SetContent(x, y, c, comb, section.TextStyle)
+				s.SetContent(x, y, c, comb, style)
 			}
 			x += w
 		}
 	}
 }
 
+func drawScrollBar(screen tcell.Screen, s Section) {
+	sb := s.Scroll
+	thumb := max(sb.Visible*sb.Visible/sb.Total, 1)
+	start := sb.Offset * sb.Visible / sb.Total
+	if sb.Offset+sb.Visible >= sb.Total {
+		start = sb.Visible - thumb
+	}
+	for i := start; i < start+thumb && i < sb.Visible; i++ {
+		screen.SetContent(s.EndX-1, s.EndY-1-i, tcell.RuneBlock, nil, s.BorderStyle)
+	}
+}
+
 func drawBox(s tcell.Screen, x1, y1, x2, y2 int, style tcell.Style) {
 	if y2 < y1 {
 		y1, y2 = y2, y1
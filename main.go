@@ -2,11 +2,18 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 
 	"github.com/urfave/cli/v3"
 
+	"github.com/StateOfDenial/tfpd/cmd/importblock"
+	"github.com/StateOfDenial/tfpd/cmd/pick"
 	"github.com/StateOfDenial/tfpd/cmd/providers"
+	"github.com/StateOfDenial/tfpd/cmd/scaffold"
+	"github.com/StateOfDenial/tfpd/cmd/wrap"
+	"github.com/StateOfDenial/tfpd/internal/tui"
 )
 
 func main() {
@@ -15,11 +22,20 @@ func main() {
 		Usage: "Terraform provider docs getter",
 		Commands: []*cli.Command{
 			providers.Command(),
+			providers.SelectCommand(),
+			providers.HistoryCommand(),
+			scaffold.Command(),
+			importblock.Command(),
+			wrap.Command(),
+			pick.Command(),
 		},
 	}
 
 	if err := app.Run(context.Background(), os.Args); err != nil {
-		// urfave/cli handles error output
+		if errors.Is(err, tui.ErrAborted) {
+			os.Exit(130)
+		}
+		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 }
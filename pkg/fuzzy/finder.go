@@ -0,0 +1,315 @@
+// Package fuzzy is tfpd's terminal fuzzy finder, for picking one or more
+// values of any type from a list.
+package fuzzy
+
+import (
+	"errors"
+	"slices"
+	"sync"
+
+	"github.com/StateOfDenial/tfpd/internal/tui"
+)
+
+var (
+	// ErrAborted is returned when the user quits the finder with Esc or
+	// Ctrl-C.
+	ErrAborted = tui.ErrAborted
+	// ErrNoMatch is returned when there was nothing to pick.
+	ErrNoMatch = tui.ErrNoMatch
+	// ErrBack is returned when the user steps back out of a finder made
+	// with SetBack.
+	ErrBack = tui.ErrBack
+)
+
+type Finder[T any] struct {
+	display   func(T) string
+	search    func(T) string
+	columns   func(T) []string
+	match     []int
+	preview   func(T) string
+	markdown  bool
+	category  func(T) string
+	group     func(T) string
+	boost     func(T) int
+	history   []string
+	lastQuery string
+	lastTab   string
+	active    string
+	back      bool
+	cursor    func(T) bool
+	query     string
+	selectOne bool
+	exitZero  bool
+	mouse     bool
+	items     []T
+	stream    <-chan T
+	streamErr func() error
+}
+
+// New returns a finder that lists each item as display(item). display can be
+// nil if the items are shown with SetColumns, and Find fails if neither is.
+func New[T any](display func(T) string) *Finder[T] {
+	return &Finder[T]{display: display, mouse: true}
+}
+
+// SetSearch matches items against search(item) instead of their display
+// text.
+func (f *Finder[T]) SetSearch(search func(T) string) *Finder[T] {
+	f.search = search
+	return f
+}
+
+// SetColumns lists each item as columns(item) lined up in columns, in place
+// of its display text. Unless SetSearch is used, items match on the columns
+// at the indexes in match, in that order, or on every column if none are
+// given.
+func (f *Finder[T]) SetColumns(columns func(T) []string, match ...int) *Finder[T] {
+	f.columns, f.match = columns, match
+	return f
+}
+
+// SetPreview shows preview(item) beside the list for the highlighted item.
+// It is called off the UI goroutine and its results are cached.
+func (f *Finder[T]) SetPreview(preview func(T) string) *Finder[T] {
+	f.preview, f.markdown = preview, false
+	return f
+}
+
+// SetMarkdownPreview is SetPreview for previews written in markdown, which
+// are shown rendered rather than as plain text.
+func (f *Finder[T]) SetMarkdownPreview(preview func(T) string) *Finder[T] {
+	f.preview, f.markdown = preview, true
+	return f
+}
+
+// SetCategories gives each distinct category(item) a tab in the finder,
+// switched between with Tab and Shift-Tab, or Alt-1 to Alt-9.
+func (f *Finder[T]) SetCategories(category func(T) string) *Finder[T] {
+	f.category = category
+	return f
+}
+
+// SetActiveCategory opens the finder on the tab for category rather than on
+// every item.
+func (f *Finder[T]) SetActiveCategory(category string) *Finder[T] {
+	f.active = category
+	return f
+}
+
+// SetGroups lists items as a tree, under a header for each distinct
+// group(item). Enter opens and closes a group, and searching opens every
+// group with a match.
+func (f *Finder[T]) SetGroups(group func(T) string) *Finder[T] {
+	f.group = group
+	return f
+}
+
+// SetBoost adds boost(item) to each item's match score, ranking it ahead of
+// others even before anything is typed.
+func (f *Finder[T]) SetBoost(boost func(T) int) *Finder[T] {
+	f.boost = boost
+	return f
+}
+
+// SetHistory lets Up and Down on an empty prompt recall queries, newest
+// first.
+func (f *Finder[T]) SetHistory(queries []string) *Finder[T] {
+	f.history = queries
+	return f
+}
+
+// LastQuery returns what was typed when the last Find or FindMulti ended.
+func (f *Finder[T]) LastQuery() string {
+	return f.lastQuery
+}
+
+// LastCategory returns the tab the finder was on when the last Find or
+// FindMulti ended, or "" for the tab of every item.
+func (f *Finder[T]) LastCategory() string {
+	return f.lastTab
+}
+
+// SetBack makes Esc, or Backspace with nothing typed, return ErrBack
+// instead of ErrAborted, for finders that are one step of several.
+func (f *Finder[T]) SetBack(back bool) *Finder[T] {
+	f.back = back
+	return f
+}
+
+// SetCursor opens the finder with the cursor on the first item that at
+// returns true for, such as the one picked last time. It only applies to
+// items given with SetItems.
+func (f *Finder[T]) SetCursor(at func(T) bool) *Finder[T] {
+	f.cursor = at
+	return f
+}
+
+// SetQuery starts the search at query.
+func (f *Finder[T]) SetQuery(query string) *Finder[T] {
+	f.query = query
+	return f
+}
+
+// SetSelectOne returns the only item matching the initial query without
+// waiting for the user.
+func (f *Finder[T]) SetSelectOne(selectOne bool) *Finder[T] {
+	f.selectOne = selectOne
+	return f
+}
+
+// SetExitZero returns ErrNoMatch without waiting for the user if nothing
+// matches the initial query.
+func (f *Finder[T]) SetExitZero(exitZero bool) *Finder[T] {
+	f.exitZero = exitZero
+	return f
+}
+
+// SetMouse turns clicking and scrolling in the finder on or off. It's on
+// unless turned off.
+func (f *Finder[T]) SetMouse(enabled bool) *Finder[T] {
+	f.mouse = enabled
+	return f
+}
+
+func (f *Finder[T]) SetItems(items []T) *Finder[T] {
+	f.items, f.stream = items, nil
+	return f
+}
+
+// StreamItems lets the finder open before every item is known, adding them
+// as they arrive on items. Close items once there are no more.
+func (f *Finder[T]) StreamItems(items <-chan T) *Finder[T] {
+	f.items, f.stream = nil, items
+	return f
+}
+
+// SetStreamErr has the finder call err once the stream from StreamItems is
+// closed. If it reports an error, the finder closes and Find returns it.
+func (f *Finder[T]) SetStreamErr(err func() error) *Finder[T] {
+	f.streamErr = err
+	return f
+}
+
+// Find lets the user pick one item.
+func (f *Finder[T]) Find() (T, error) {
+	found, err := f.find(false)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return found[0], nil
+}
+
+// FindMulti lets the user pick several items with Tab, returning them in the
+// order they were picked.
+func (f *Finder[T]) FindMulti() ([]T, error) {
+	return f.find(true)
+}
+
+func (f *Finder[T]) item(t T) tui.Item {
+	var item tui.Item
+	if f.columns != nil {
+		item.Fields = f.columns(t)
+	} else {
+		item.Display = f.display(t)
+		item.Search = item.Display
+	}
+	if f.search != nil {
+		item.Search = f.search(t)
+	}
+	if f.category != nil {
+		item.Category = f.category(t)
+	}
+	if f.group != nil {
+		item.Group = f.group(t)
+	}
+	if f.boost != nil {
+		item.Boost = f.boost(t)
+	}
+	return item
+}
+
+func (f *Finder[T]) find(multi bool) ([]T, error) {
+	if f.display == nil && f.columns == nil {
+		f.discardStream()
+		return nil, errors.New("fuzzy: a finder needs a display func or SetColumns")
+	}
+	ff, err := tui.NewFuzzyFinder()
+	if err != nil {
+		f.discardStream()
+		return nil, err
+	}
+	ff.SetColumns(f.match...)
+	ff.SetSearchInput(f.query).SetSelectOne(f.selectOne).SetExitZero(f.exitZero).SetCategory(f.active).SetTree(f.group != nil).SetHistory(f.history).SetBack(f.back).SetMouse(f.mouse)
+
+	var mu sync.Mutex
+	received := f.items
+	lookup := func(id int) T {
+		mu.Lock()
+		defer mu.Unlock()
+		return received[id]
+	}
+
+	if f.stream != nil {
+		items := make(chan tui.Item)
+		go func() {
+			defer close(items)
+			for t := range f.stream {
+				mu.Lock()
+				received = append(received, t)
+				mu.Unlock()
+				items <- f.item(t)
+			}
+		}()
+		ff.StreamItems(items).SetStreamErr(f.streamErr)
+	} else {
+		items := make([]tui.Item, len(f.items))
+		for i, t := range f.items {
+			items[i] = f.item(t)
+		}
+		ff.SetItems(items)
+		if f.cursor != nil {
+			if i := slices.IndexFunc(f.items, f.cursor); i >= 0 {
+				ff.SetCursorItem(i)
+			}
+		}
+	}
+	if f.preview != nil {
+		preview := func(id int) string { return f.preview(lookup(id)) }
+		if f.markdown {
+			ff.SetMarkdownPreview(preview)
+		} else {
+			ff.SetPreview(preview)
+		}
+	}
+
+	var ids []int
+	if multi {
+		ids, err = ff.FuzzyFindMulti()
+	} else {
+		var id int
+		id, err = ff.FuzzyFind()
+		ids = []int{id}
+	}
+	f.lastQuery, f.lastTab = ff.Query(), ff.Category()
+	if err != nil {
+		return nil, err
+	}
+
+	found := make([]T, len(ids))
+	for i, id := range ids {
+		found[i] = lookup(id)
+	}
+	return found, nil
+}
+
+// discardStream drains the stream of a finder that won't open, so whatever
+// is sending on it isn't left blocked.
+func (f *Finder[T]) discardStream() {
+	if f.stream != nil {
+		go func() {
+			for range f.stream {
+			}
+		}()
+	}
+}
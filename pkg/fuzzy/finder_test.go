@@ -0,0 +1,16 @@
+package fuzzy
+
+import "testing"
+
+func TestFindWithoutDisplay(t *testing.T) {
+	if _, err := New[string](nil).SetItems([]string{"a"}).Find(); err == nil {
+		t.Error("Find with no display func or columns didn't fail")
+	}
+	stream := make(chan string)
+	if _, err := New[string](nil).StreamItems(stream).FindMulti(); err == nil {
+		t.Error("FindMulti with no display func or columns didn't fail")
+	}
+	// The stream is drained rather than left blocking its sender.
+	stream <- "a"
+	close(stream)
+}